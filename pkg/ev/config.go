@@ -0,0 +1,640 @@
+package ev
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/allegro/bigcache"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evcache"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/gocache/marshaler"
+	"github.com/prodadidb/gocache/store"
+	"gopkg.in/yaml.v3"
+)
+
+// Keys of CacheConfig
+const (
+	// EmailCacheKey caches results by email, see EmailCacheKeyGetter
+	EmailCacheKey = "email"
+	// DomainCacheKey caches results by domain, see DomainCacheKeyGetter
+	DomainCacheKey = "domain"
+)
+
+// Keys of RateLimitConfig
+const (
+	// DomainLimitKey limits validations by domain, see DomainRateLimitKey
+	DomainLimitKey = "domain"
+	// MXLimitKey limits validations by MX host, see MXRateLimitKey
+	MXLimitKey = "mx"
+)
+
+// DefaultCacheTTL is used if CacheConfig.TTL is not set
+const DefaultCacheTTL = time.Hour
+
+// Config describes DepValidator, see ParseConfig
+type Config struct {
+	// Policy is name of ExecutionPolicy: runAll, stopOnFirstError or skipExpensive
+	Policy     string            `json:"policy,omitempty" yaml:"policy,omitempty"`
+	Timeout    time.Duration     `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Expensive  []ValidatorName   `json:"expensive,omitempty" yaml:"expensive,omitempty"`
+	Blocking   []ValidatorName   `json:"blocking,omitempty" yaml:"blocking,omitempty"`
+	Validators []ValidatorConfig `json:"validators" yaml:"validators"`
+
+	// registry is used to instantiate validators, DefaultRegistry is used if it is nil
+	registry *Registry
+	// dir is used to resolve relative ListConfig.File
+	dir string
+}
+
+// ValidatorConfig describes validator by name of Factory in Registry
+type ValidatorConfig struct {
+	Name ValidatorName `json:"name" yaml:"name"`
+	// After are validators, which should be finished before the validator
+	After   []ValidatorName `json:"after,omitempty" yaml:"after,omitempty"`
+	Timeout time.Duration   `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// RateLimit wraps validator by RateLimitDecorator, retries are limited too
+	RateLimit *RateLimitConfig `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty"`
+	// Retry wraps validator by RetryDecorator, default values are used for absent fields
+	Retry    *RetryPolicy    `json:"retry,omitempty" yaml:"retry,omitempty"`
+	Warnings *WarningsConfig `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	Cache    *CacheConfig    `json:"cache,omitempty" yaml:"cache,omitempty"`
+	// Params are the rest of fields, they are decoded into Factory.Params, unknown fields are forbidden.
+	// E.g. list of ListParams, pattern of SyntaxParams, url of GravatarParams or smtp of SMTPParams.
+	Params map[string]yaml.Node `json:"-" yaml:",inline"`
+}
+
+// ListConfig is source of values for contains-based validators, Items and lines of File are merged.
+// Empty lines and lines started with # are skipped in File.
+type ListConfig struct {
+	Items []string `json:"items,omitempty" yaml:"items,omitempty"`
+	File  string   `json:"file,omitempty" yaml:"file,omitempty"`
+
+	// dir is used to resolve relative File
+	dir string
+}
+
+// SMTPConfig is fields of evsmtp.OptionsDTO
+type SMTPConfig struct {
+	EmailFrom         string        `json:"emailFrom,omitempty" yaml:"emailFrom,omitempty"`
+	HelloName         string        `json:"helloName,omitempty" yaml:"helloName,omitempty"`
+	Proxy             string        `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	TimeoutConnection time.Duration `json:"timeoutConnection,omitempty" yaml:"timeoutConnection,omitempty"`
+	TimeoutResponse   time.Duration `json:"timeoutResponse,omitempty" yaml:"timeoutResponse,omitempty"`
+	Port              int           `json:"port,omitempty" yaml:"port,omitempty"`
+	// CircuitBreaker enables evsmtp.CircuitBreaker for MX hosts
+	CircuitBreaker *CircuitBreakerConfig `json:"circuitBreaker,omitempty" yaml:"circuitBreaker,omitempty"`
+}
+
+// CircuitBreakerConfig is fields of evsmtp.CircuitBreakerDTO, default values are used for absent fields
+type CircuitBreakerConfig struct {
+	Threshold int           `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+	CoolDown  time.Duration `json:"coolDown,omitempty" yaml:"coolDown,omitempty"`
+}
+
+// WarningsConfig moves error to warnings if it matches any of rules
+type WarningsConfig struct {
+	// Stages match evsmtp.Error by evsmtp.StageName
+	Stages []string `json:"stages,omitempty" yaml:"stages,omitempty"`
+	// Errors match error by names of its types in the chain of wrapped errors
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+	// Codes match SMTP reply code
+	Codes []int `json:"codes,omitempty" yaml:"codes,omitempty"`
+}
+
+// RateLimitConfig is configuration of RateLimitDecorator
+type RateLimitConfig struct {
+	// Key is domain or mx, domain is default
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+	// Wait waits for limits instead of returning of RateLimitedError
+	Wait      bool                 `json:"wait,omitempty" yaml:"wait,omitempty"`
+	Default   RateLimit            `json:"default,omitempty" yaml:"default,omitempty"`
+	Providers map[string]RateLimit `json:"providers,omitempty" yaml:"providers,omitempty"`
+}
+
+func (r RateLimitConfig) validate() error {
+	switch r.Key {
+	case "", DomainLimitKey, MXLimitKey:
+	default:
+		return &ParamsError{Field: "key", Reason: fmt.Sprintf("unknown key %q, %s or %s is expected", r.Key, DomainLimitKey, MXLimitKey)}
+	}
+
+	if err := r.Default.Validate(); err != nil {
+		err.(*ParamsError).Field = "default." + err.(*ParamsError).Field
+		return err
+	}
+
+	for provider, limit := range r.Providers {
+		if err := limit.Validate(); err != nil {
+			err.(*ParamsError).Field = "providers." + provider + "." + err.(*ParamsError).Field
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CacheConfig wraps validator by CacheDecorator with in-memory cache
+type CacheConfig struct {
+	// Key is email or domain, email is default
+	Key string        `json:"key,omitempty" yaml:"key,omitempty"`
+	TTL time.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+}
+
+// ConfigError is error of ParseConfig, LoadConfig and Config.Build
+type ConfigError struct {
+	// Path is path to invalid field, e.g. validators.SMTPValidator.smtp.port
+	Path   string
+	Reason string
+}
+
+func (c *ConfigError) Error() string {
+	if c.Path == "" {
+		return "ConfigError: " + c.Reason
+	}
+
+	return "ConfigError: " + c.Path + ": " + c.Reason
+}
+
+func newConfigError(path, format string, a ...interface{}) error {
+	return &ConfigError{Path: path, Reason: fmt.Sprintf(format, a...)}
+}
+
+// configErrorOf converts error of Registry to ConfigError
+func configErrorOf(path string, err error) error {
+	var paramsErr *ParamsError
+	if errors.As(err, &paramsErr) {
+		if paramsErr.Field != "" {
+			path += "." + paramsErr.Field
+		}
+		return &ConfigError{Path: path, Reason: paramsErr.Reason}
+	}
+
+	return &ConfigError{Path: path, Reason: err.Error()}
+}
+
+// ParseConfig parses and validates Config from YAML or JSON with DefaultRegistry, see Registry.ParseConfig
+func ParseConfig(data []byte) (Config, error) {
+	return DefaultRegistry().ParseConfig(data)
+}
+
+// LoadConfig reads Config from file with DefaultRegistry, see Registry.LoadConfig
+func LoadConfig(path string) (Config, error) {
+	return DefaultRegistry().LoadConfig(path)
+}
+
+// ParseConfig parses and validates Config from YAML or JSON, validators are instantiated by factories of Registry.
+// Unknown fields are forbidden. Durations are strings like "1m30s".
+// Relative ListConfig.File is resolved from the working directory.
+func (r *Registry) ParseConfig(data []byte) (Config, error) {
+	config := Config{registry: r}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&config); err != nil {
+		return config, &ConfigError{Reason: err.Error()}
+	}
+
+	return config, config.Validate()
+}
+
+// LoadConfig reads Config from file, relative ListConfig.File is resolved from the directory of the file
+func (r *Registry) LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, &ConfigError{Reason: err.Error()}
+	}
+
+	config, err := r.ParseConfig(data)
+	config.dir = filepath.Dir(path)
+
+	return config, err
+}
+
+func (c Config) getRegistry() *Registry {
+	if c.registry == nil {
+		return DefaultRegistry()
+	}
+
+	return c.registry
+}
+
+// Validate checks names of validators, policy, stages, durations and parameters of validators
+func (c Config) Validate() error {
+	registry := c.getRegistry()
+
+	if _, ok := ExecutionPolicyByName(c.Policy); c.Policy != "" && !ok {
+		return newConfigError("policy", "unknown policy %q", c.Policy)
+	}
+
+	if c.Timeout < 0 {
+		return newConfigError("timeout", "negative duration %v", c.Timeout)
+	}
+
+	if len(c.Validators) == 0 {
+		return newConfigError("validators", "at least one validator is required")
+	}
+
+	names := make(map[ValidatorName]bool, len(c.Validators))
+	for i, validator := range c.Validators {
+		path := fmt.Sprintf("validators[%d].name", i)
+		if _, ok := registry.Get(validator.Name); !ok {
+			return newConfigError(path, "unknown validator %q", validator.Name)
+		}
+		if names[validator.Name] {
+			return newConfigError(path, "duplicate validator %q", validator.Name)
+		}
+		names[validator.Name] = true
+	}
+
+	if err := checkConfigNames("expensive", c.Expensive, names); err != nil {
+		return err
+	}
+	if err := checkConfigNames("blocking", c.Blocking, names); err != nil {
+		return err
+	}
+
+	for _, validator := range c.Validators {
+		if err := validator.validate(registry, names); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkConfigNames(path string, values []ValidatorName, names map[ValidatorName]bool) error {
+	for _, name := range values {
+		if !names[name] {
+			return newConfigError(path, "validator %q is not configured", name)
+		}
+	}
+
+	return nil
+}
+
+func (v ValidatorConfig) validate(registry *Registry, names map[ValidatorName]bool) error {
+	path := "validators." + v.Name.String()
+
+	if err := checkConfigNames(path+".after", v.After, names); err != nil {
+		return err
+	}
+
+	if v.Timeout < 0 {
+		return newConfigError(path+".timeout", "negative duration %v", v.Timeout)
+	}
+
+	if _, err := v.params(registry, ""); err != nil {
+		return err
+	}
+
+	if v.RateLimit != nil {
+		if err := v.RateLimit.validate(); err != nil {
+			return configErrorOf(path+".rateLimit", err)
+		}
+	}
+
+	if v.Retry != nil {
+		if err := v.Retry.Validate(); err != nil {
+			return configErrorOf(path+".retry", err)
+		}
+	}
+
+	if v.Warnings != nil {
+		for _, stage := range v.Warnings.Stages {
+			if _, ok := evsmtp.StageByName(stage); !ok {
+				return newConfigError(path+".warnings.stages", "unknown stage %q", stage)
+			}
+		}
+	}
+
+	if v.Cache != nil {
+		switch v.Cache.Key {
+		case "", EmailCacheKey, DomainCacheKey:
+		default:
+			return newConfigError(path+".cache.key", "unknown key %q, %s or %s is expected", v.Cache.Key, EmailCacheKey, DomainCacheKey)
+		}
+		if v.Cache.TTL < 0 {
+			return newConfigError(path+".cache.ttl", "negative duration %v", v.Cache.TTL)
+		}
+	}
+
+	return nil
+}
+
+func (s SMTPConfig) validate() error {
+	if s.EmailFrom != "" {
+		if email := evmail.FromString(s.EmailFrom); email.Username() == "" || email.Domain() == "" {
+			return &ParamsError{Field: "smtp.emailFrom", Reason: fmt.Sprintf("invalid email %q", s.EmailFrom)}
+		}
+	}
+
+	if s.Port < 0 || s.Port > 65535 {
+		return &ParamsError{Field: "smtp.port", Reason: fmt.Sprintf("port %d is out of range", s.Port)}
+	}
+
+	if s.TimeoutConnection < 0 {
+		return &ParamsError{Field: "smtp.timeoutConnection", Reason: fmt.Sprintf("negative duration %v", s.TimeoutConnection)}
+	}
+
+	if s.TimeoutResponse < 0 {
+		return &ParamsError{Field: "smtp.timeoutResponse", Reason: fmt.Sprintf("negative duration %v", s.TimeoutResponse)}
+	}
+
+	if s.CircuitBreaker != nil {
+		if s.CircuitBreaker.Threshold < 0 {
+			return &ParamsError{Field: "smtp.circuitBreaker.threshold", Reason: fmt.Sprintf("negative number %d", s.CircuitBreaker.Threshold)}
+		}
+		if s.CircuitBreaker.CoolDown < 0 {
+			return &ParamsError{Field: "smtp.circuitBreaker.coolDown", Reason: fmt.Sprintf("negative duration %v", s.CircuitBreaker.CoolDown)}
+		}
+	}
+
+	return nil
+}
+
+// NewCircuitBreaker returns evsmtp.CircuitBreaker or nil if CircuitBreaker is not configured
+func (s SMTPConfig) NewCircuitBreaker() *evsmtp.CircuitBreaker {
+	if s.CircuitBreaker == nil {
+		return nil
+	}
+
+	return evsmtp.NewCircuitBreaker(evsmtp.CircuitBreakerDTO{
+		Threshold: s.CircuitBreaker.Threshold,
+		CoolDown:  s.CircuitBreaker.CoolDown,
+	})
+}
+
+// Options returns evsmtp.Options, default timeouts are used if they are not set
+func (s SMTPConfig) Options() evsmtp.Options {
+	dto := evsmtp.OptionsDTO{
+		HelloName:   s.HelloName,
+		Proxy:       s.Proxy,
+		TimeoutCon:  s.TimeoutConnection,
+		TimeoutResp: s.TimeoutResponse,
+		Port:        s.Port,
+	}
+	if s.EmailFrom != "" {
+		dto.EmailFrom = evmail.FromString(s.EmailFrom)
+	}
+	if dto.TimeoutCon == 0 {
+		dto.TimeoutCon = evsmtp.DefaultTimeoutConnection
+	}
+	if dto.TimeoutResp == 0 {
+		dto.TimeoutResp = evsmtp.DefaultTimeoutResponse
+	}
+
+	return evsmtp.NewOptions(dto)
+}
+
+// Builder validates Config, reads lists and forms DepBuilder
+func (c Config) Builder() (*DepBuilder, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	registry := c.getRegistry()
+	builder := NewDepBuilder(ValidatorMap{}).SetRegistry(registry)
+	for _, validatorConfig := range c.Validators {
+		validator, err := validatorConfig.validator(registry, c.dir)
+		if err != nil {
+			return nil, err
+		}
+
+		builder.Set(validatorConfig.Name, validator)
+		if validatorConfig.Timeout > 0 {
+			builder.SetTimeout(validatorConfig.Name, validatorConfig.Timeout)
+		}
+		if len(validatorConfig.After) > 0 {
+			builder.SetAfter(validatorConfig.Name, validatorConfig.After...)
+		}
+	}
+
+	if c.Policy != "" {
+		policy, _ := ExecutionPolicyByName(c.Policy)
+		builder.SetPolicy(policy)
+	}
+	if c.Expensive != nil {
+		builder.SetExpensive(c.Expensive...)
+	}
+	if c.Blocking != nil {
+		builder.SetBlocking(c.Blocking...)
+	}
+	builder.SetTotalTimeout(c.Timeout)
+
+	return builder, nil
+}
+
+// Build forms Validator by Config.
+// UnknownDepError or CycleDepError is returned if dependencies of validators are not configured.
+func (c Config) Build() (Validator, error) {
+	builder, err := c.Builder()
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.BuildE()
+}
+
+// params decodes Params into params of Factory, relative ListConfig.File is resolved from dir
+func (v ValidatorConfig) params(registry *Registry, dir string) (interface{}, error) {
+	params, err := registry.Params(v.Name, v.decodeParams)
+	if err != nil {
+		return nil, configErrorOf("validators."+v.Name.String(), err)
+	}
+
+	if setter, ok := params.(interface{ setDir(dir string) }); ok {
+		setter.setDir(dir)
+	}
+
+	return params, nil
+}
+
+// decodeParams decodes Params strictly, yaml.Node.Decode does not support yaml.Decoder.KnownFields
+func (v ValidatorConfig) decodeParams(params interface{}) error {
+	keys := make([]string, 0, len(v.Params))
+	for key := range v.Params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range keys {
+		value := v.Params[key]
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &value)
+	}
+
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err = dec.Decode(params); err != nil {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			// lines of the marshaled node differ from lines of the document
+			reasons := make([]string, len(typeErr.Errors))
+			for i, reason := range typeErr.Errors {
+				reasons[i] = yamlLinePrefix.ReplaceAllString(reason, "")
+			}
+			return errors.New(strings.Join(reasons, "; "))
+		}
+		return err
+	}
+
+	return nil
+}
+
+var yamlLinePrefix = regexp.MustCompile(`^line \d+: `)
+
+func (v ValidatorConfig) validator(registry *Registry, dir string) (Validator, error) {
+	path := "validators." + v.Name.String()
+
+	params, err := v.params(registry, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := registry.New(v.Name, params)
+	if err != nil {
+		return nil, configErrorOf(path, err)
+	}
+
+	if v.RateLimit != nil {
+		getKey := DomainRateLimitKey
+		if v.RateLimit.Key == MXLimitKey {
+			getKey = MXRateLimitKey
+		}
+		validator = NewRateLimitDecorator(validator, RateLimitDTO{
+			Name:      v.Name,
+			GetKey:    getKey,
+			Default:   v.RateLimit.Default,
+			Providers: v.RateLimit.Providers,
+			Wait:      v.RateLimit.Wait,
+		})
+	}
+
+	if v.Retry != nil {
+		policy := DefaultRetryPolicy()
+		if v.Retry.Attempts != 0 {
+			policy.Attempts = v.Retry.Attempts
+		}
+		if v.Retry.Delay != 0 {
+			policy.Delay = v.Retry.Delay
+		}
+		if v.Retry.Multiplier != 0 {
+			policy.Multiplier = v.Retry.Multiplier
+		}
+		if v.Retry.MaxDelay != 0 {
+			policy.MaxDelay = v.Retry.MaxDelay
+		}
+		if v.Retry.Jitter != 0 {
+			policy.Jitter = v.Retry.Jitter
+		}
+		validator = NewRetryDecorator(validator, policy)
+	}
+
+	if v.Warnings != nil {
+		validator = NewWarningsDecorator(validator, v.Warnings.isWarning)
+	}
+
+	if v.Cache != nil {
+		cache, err := v.Cache.cache()
+		if err != nil {
+			return nil, newConfigError(path+".cache", "%v", err)
+		}
+
+		getKey := EmailCacheKeyGetter
+		if v.Cache.Key == DomainCacheKey {
+			getKey = DomainCacheKeyGetter
+		}
+		validator = NewCacheDecorator(validator, cache, getKey)
+	}
+
+	return validator, nil
+}
+
+// values returns lowercased Items and lines of File, because evmail.Address is lowercased
+func (l ListConfig) values() ([]string, error) {
+	values := make([]string, 0, len(l.Items))
+	for _, item := range l.Items {
+		values = append(values, strings.ToLower(strings.TrimSpace(item)))
+	}
+
+	if l.File == "" {
+		return values, nil
+	}
+
+	path := l.File
+	if !filepath.IsAbs(path) && l.dir != "" {
+		path = filepath.Join(l.dir, path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		values = append(values, strings.ToLower(line))
+	}
+
+	return values, scanner.Err()
+}
+
+func (w WarningsConfig) isWarning(err error) bool {
+	if len(w.Stages) > 0 {
+		var smtpErr evsmtp.Error
+		if errors.As(err, &smtpErr) && containsAny([]string{evsmtp.StageName(smtpErr.Stage())}, w.Stages) {
+			return true
+		}
+	}
+
+	if len(w.Errors) > 0 && containsAny(errorTypeNames(err), w.Errors) {
+		return true
+	}
+
+	var protoErr *textproto.Error
+	if len(w.Codes) > 0 && errors.As(err, &protoErr) {
+		for _, code := range w.Codes {
+			if protoErr.Code == code {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c CacheConfig) cache() (evcache.Interface, error) {
+	ttl := c.TTL
+	if ttl == 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	client, err := bigcache.NewBigCache(bigcache.DefaultConfig(ttl))
+	if err != nil {
+		return nil, err
+	}
+
+	return evcache.NewCacheMarshaller(marshaler.New(store.NewBigcache(client)), func() interface{} {
+		return new(ValidationResult)
+	}), nil
+}
@@ -0,0 +1,297 @@
+package ev_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/stretchr/testify/require"
+)
+
+const configYAML = `
+policy: skipExpensive
+timeout: 10s
+expensive: [SMTPValidator]
+blocking: [syntaxValidator, BlackListDomains]
+validators:
+  - name: syntaxValidator
+  - name: BlackListDomains
+    list:
+      items: [Spam.com]
+      file: domains.txt
+    cache:
+      key: domain
+      ttl: 1m
+  - name: BanWordsUsername
+    list:
+      items: [admin]
+  - name: RoleValidator
+    after: [BlackListDomains]
+  - name: MXValidator
+    timeout: 2s
+    retry:
+      attempts: 2
+  - name: SMTPValidator
+    timeout: 5s
+    rateLimit:
+      key: mx
+      default: {rate: 1, burst: 5, concurrency: 2}
+      providers:
+        google.com: {rate: 10, concurrency: 5}
+    smtp:
+      emailFrom: check@domain.com
+      helloName: domain.com
+      timeoutConnection: 3s
+      port: 587
+      circuitBreaker:
+        threshold: 3
+`
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantPath string
+		wantErr  bool
+	}{
+		{
+			name: "yaml",
+			data: configYAML,
+		},
+		{
+			name: "json",
+			data: `{"validators": [{"name": "syntaxValidator", "pattern": "^.+@.+$"}, {"name": "Gravatar", "url": "http://localhost/"}]}`,
+		},
+		{
+			name:     "unknown field",
+			data:     `{"validators": [{"name": "syntaxValidator", "patern": "^.+@.+$"}]}`,
+			wantPath: "validators.syntaxValidator",
+		},
+		{
+			name:     "unknown nested field",
+			data:     `{"validators": [{"name": "SMTPValidator", "smtp": {"prot": 25}}]}`,
+			wantPath: "validators.SMTPValidator",
+		},
+		{
+			name:    "invalid duration",
+			data:    `{"timeout": 10, "validators": [{"name": "syntaxValidator"}]}`,
+			wantErr: true,
+		},
+		{
+			name:     "unknown policy",
+			data:     `{"policy": "skip", "validators": [{"name": "syntaxValidator"}]}`,
+			wantPath: "policy",
+		},
+		{
+			name:     "without validators",
+			data:     `{"policy": "runAll"}`,
+			wantPath: "validators",
+		},
+		{
+			name:     "unknown validator",
+			data:     `{"validators": [{"name": "syntaxValidator"}, {"name": "Unknown"}]}`,
+			wantPath: "validators[1].name",
+		},
+		{
+			name:     "duplicate validator",
+			data:     `{"validators": [{"name": "syntaxValidator"}, {"name": "syntaxValidator"}]}`,
+			wantPath: "validators[1].name",
+		},
+		{
+			name:     "unknown expensive",
+			data:     `{"expensive": ["SMTPValidator"], "validators": [{"name": "syntaxValidator"}]}`,
+			wantPath: "expensive",
+		},
+		{
+			name:     "unknown after",
+			data:     `{"validators": [{"name": "syntaxValidator", "after": ["MXValidator"]}]}`,
+			wantPath: "validators.syntaxValidator.after",
+		},
+		{
+			name:     "list is required",
+			data:     `{"validators": [{"name": "BlackListEmails"}]}`,
+			wantPath: "validators.BlackListEmails.list",
+		},
+		{
+			name:     "empty list",
+			data:     `{"validators": [{"name": "RoleValidator", "list": {}}]}`,
+			wantPath: "validators.RoleValidator.list",
+		},
+		{
+			name:     "list is not supported",
+			data:     `{"validators": [{"name": "MXValidator", "list": {"items": ["a"]}}]}`,
+			wantPath: "validators.MXValidator",
+		},
+		{
+			name:     "smtp is not supported",
+			data:     `{"validators": [{"name": "MXValidator", "smtp": {"port": 25}}]}`,
+			wantPath: "validators.MXValidator",
+		},
+		{
+			name:     "invalid pattern",
+			data:     `{"validators": [{"name": "syntaxValidator", "pattern": "("}]}`,
+			wantPath: "validators.syntaxValidator.pattern",
+		},
+		{
+			name:     "invalid port",
+			data:     `{"validators": [{"name": "SMTPValidator", "smtp": {"port": 70000}}]}`,
+			wantPath: "validators.SMTPValidator.smtp.port",
+		},
+		{
+			name:     "invalid email from",
+			data:     `{"validators": [{"name": "SMTPValidator", "smtp": {"emailFrom": "user"}}]}`,
+			wantPath: "validators.SMTPValidator.smtp.emailFrom",
+		},
+		{
+			name:     "invalid circuit breaker",
+			data:     `{"validators": [{"name": "SMTPValidator", "smtp": {"circuitBreaker": {"threshold": -1}}}]}`,
+			wantPath: "validators.SMTPValidator.smtp.circuitBreaker.threshold",
+		},
+		{
+			name:     "unknown stage",
+			data:     `{"validators": [{"name": "SMTPValidator", "warnings": {"stages": ["rcpt"]}}]}`,
+			wantPath: "validators.SMTPValidator.warnings.stages",
+		},
+		{
+			name:     "invalid retry",
+			data:     `{"validators": [{"name": "MXValidator", "retry": {"jitter": 2}}]}`,
+			wantPath: "validators.MXValidator.retry.jitter",
+		},
+		{
+			name:     "unknown rate limit key",
+			data:     `{"validators": [{"name": "MXValidator", "rateLimit": {"key": "email"}}]}`,
+			wantPath: "validators.MXValidator.rateLimit.key",
+		},
+		{
+			name:     "invalid provider rate limit",
+			data:     `{"validators": [{"name": "MXValidator", "rateLimit": {"providers": {"google.com": {"rate": -1}}}}]}`,
+			wantPath: "validators.MXValidator.rateLimit.providers.google.com.rate",
+		},
+		{
+			name:     "unknown cache key",
+			data:     `{"validators": [{"name": "MXValidator", "cache": {"key": "username"}}]}`,
+			wantPath: "validators.MXValidator.cache.key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ev.ParseConfig([]byte(tt.data))
+			if !tt.wantErr && tt.wantPath == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var configErr *ev.ConfigError
+			require.True(t, errors.As(err, &configErr), err)
+			require.Equal(t, tt.wantPath, configErr.Path)
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(configYAML), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "domains.txt"), []byte("# spam\n\nBlocked.com\n"), 0o600))
+
+	config, err := ev.LoadConfig(path)
+	require.NoError(t, err)
+
+	builder, err := config.Builder()
+	require.NoError(t, err)
+	require.Equal(t, ev.SkipExpensivePolicy, builder.Policy)
+	require.Equal(t, 10*time.Second, builder.Timeout)
+	require.Equal(t, []ev.ValidatorName{ev.SMTPValidatorName}, builder.Expensive)
+	require.Equal(t, []ev.ValidatorName{ev.SyntaxValidatorName, ev.BlackListDomainsValidatorName}, builder.Blocking)
+	require.Equal(t, map[ev.ValidatorName]time.Duration{
+		ev.MXValidatorName:   2 * time.Second,
+		ev.SMTPValidatorName: 5 * time.Second,
+	}, builder.Timeouts)
+	require.IsType(t, &ev.CacheDecorator{}, builder.Get(ev.BlackListDomainsValidatorName))
+	require.IsType(t, &ev.RetryDecorator{}, builder.Get(ev.MXValidatorName))
+	require.Equal(t, 2, builder.Get(ev.MXValidatorName).(*ev.RetryDecorator).Policy.Attempts)
+	require.IsType(t, &ev.RateLimitDecorator{}, builder.Get(ev.SMTPValidatorName))
+	require.Empty(t, builder.Get(ev.RoleValidatorName).GetDeps())
+	require.Equal(t, map[ev.ValidatorName][]ev.ValidatorName{
+		ev.RoleValidatorName: {ev.BlackListDomainsValidatorName},
+	}, builder.After)
+
+	tests := []struct {
+		email string
+		name  ev.ValidatorName
+		want  bool
+	}{
+		{email: "user@spam.com", name: ev.BlackListDomainsValidatorName, want: false},
+		{email: "user@blocked.com", name: ev.BlackListDomainsValidatorName, want: false},
+		{email: "user@domain.com", name: ev.BlackListDomainsValidatorName, want: true},
+		{email: "superadmin@domain.com", name: ev.BanWordsUsernameValidatorName, want: false},
+		{email: "info@domain.com", name: ev.RoleValidatorName, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			got := builder.Get(tt.name).Validate(ev.NewInput(evmail.FromString(tt.email)))
+			require.Equal(t, tt.want, got.IsValid())
+		})
+	}
+}
+
+func TestConfig_Build(t *testing.T) {
+	config, err := ev.ParseConfig([]byte(`{"validators": [{"name": "SMTPValidator"}]}`))
+	require.NoError(t, err)
+
+	_, err = config.Build()
+	var depErr *ev.UnknownDepError
+	require.True(t, errors.As(err, &depErr))
+
+	config, err = ev.ParseConfig([]byte(`{"validators": [{"name": "BlackListDomains", "list": {"file": "absent.txt"}}]}`))
+	require.NoError(t, err)
+
+	_, err = config.Build()
+	var configErr *ev.ConfigError
+	require.True(t, errors.As(err, &configErr))
+	require.Equal(t, "validators.BlackListDomains.list.file", configErr.Path)
+}
+
+func TestConfig_Build_Warnings(t *testing.T) {
+	config, err := ev.ParseConfig([]byte(`
+validators:
+  - name: syntaxValidator
+    warnings:
+      errors: [SyntaxError]
+`))
+	require.NoError(t, err)
+
+	validator, err := config.Build()
+	require.NoError(t, err)
+
+	result := validator.Validate(ev.NewInput(evmail.FromString("invalid")))
+	require.True(t, result.IsValid())
+	require.Len(t, result.(ev.DepValidationResult).GetResults()[ev.SyntaxValidatorName].Warnings(), 1)
+}
+
+func TestSMTPConfig_Options(t *testing.T) {
+	opts := ev.SMTPConfig{EmailFrom: "check@domain.com", Port: 587}.Options()
+
+	require.Equal(t, evmail.FromString("check@domain.com"), opts.EmailFrom())
+	require.Equal(t, 587, opts.Port())
+	require.Equal(t, evsmtp.DefaultTimeoutConnection, opts.TimeoutConnection())
+	require.Equal(t, evsmtp.DefaultTimeoutResponse, opts.TimeoutResponse())
+}
+
+func TestSMTPConfig_NewCircuitBreaker(t *testing.T) {
+	require.Nil(t, ev.SMTPConfig{}.NewCircuitBreaker())
+
+	breaker := ev.SMTPConfig{CircuitBreaker: &ev.CircuitBreakerConfig{Threshold: 1}}.NewCircuitBreaker()
+	breaker.Failure("mx.domain.com")
+	require.Equal(t, evsmtp.CircuitOpen, breaker.State("mx.domain.com"))
+}
+
+func TestConfigError_Error(t *testing.T) {
+	require.Equal(t, "ConfigError: reason", (&ev.ConfigError{Reason: "reason"}).Error())
+	require.Equal(t, "ConfigError: policy: reason", (&ev.ConfigError{Path: "policy", Reason: "reason"}).Error())
+}
@@ -2,6 +2,7 @@ package ev
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/prodadidb/go-email-validator/pkg/ev/evcache"
@@ -37,24 +38,46 @@ func NewCacheDecorator(validator Validator, cache evcache.Interface, getKey Cach
 	}
 }
 
+// typedResult is implemented by pointers to typed results, e.g. *mxValidationResult
+type typedResult interface {
+	value() ValidationResult
+}
+
 type CacheDecorator struct {
 	Validator Validator
 	Cache     evcache.Interface
 	GetKey    CacheKeyGetter
+	// OnCache is called with result of cache lookup if it is not nil, e.g. for metrics
+	OnCache func(ctx context.Context, hit bool)
 }
 
 func (c *CacheDecorator) GetDeps() []ValidatorName {
 	return c.Validator.GetDeps()
 }
 
-func (c *CacheDecorator) Validate(input Input, results ...ValidationResult) (result ValidationResult) {
+func (c *CacheDecorator) Validate(input Input, results ...ValidationResult) ValidationResult {
+	return c.ValidateContext(context.Background(), input, results...)
+}
+
+func (c *CacheDecorator) ValidateContext(ctx context.Context, input Input, results ...ValidationResult) (result ValidationResult) {
 	key := c.GetKey(input, results...)
-	ctx := context.Background()
 	resultInterface, err := c.Cache.Get(ctx, key)
-	if err == nil && resultInterface != nil {
+	hit := err == nil && resultInterface != nil
+	if c.OnCache != nil {
+		c.OnCache(ctx, hit)
+	}
+
+	if hit {
 		result = *resultInterface.(*ValidationResult)
+		// msgpack decodes typed results as pointers, they are returned as values like results of validators
+		if typed, ok := result.(typedResult); ok {
+			result = typed.value()
+		}
 	} else {
-		result = c.Validator.Validate(input, results...)
+		result = ValidateContext(ctx, c.Validator, input, results...)
+		if isInterrupted(ctx, result) {
+			return result
+		}
 		if err := c.Cache.Set(ctx, key, result); err != nil {
 			log.Logger().Error(fmt.Sprintf("cache decorator %v", err),
 				zap.String("validator", utils.StructName(c.Validator)),
@@ -67,3 +90,18 @@ func (c *CacheDecorator) Validate(input Input, results ...ValidationResult) (res
 
 	return result
 }
+
+// isInterrupted returns true if validation was interrupted by ctx or timeout, e.g. result has TimeoutError.
+// Such results are not cached, the validation should be repeated.
+func isInterrupted(ctx context.Context, result ValidationResult) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	for _, err := range result.Errors() {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/prodadidb/go-email-validator/pkg/ev/evcache"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
 	"github.com/prodadidb/gocache/marshaler"
 	"github.com/prodadidb/gocache/store"
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,8 @@ func Test_cacheDecorator_Validate(t *testing.T) {
 
 	results := make([]ev.ValidationResult, 0)
 	key := validEmail.String()
+	timeoutResult := ev.NewResultWithOutcome(ev.OutcomeUnknown,
+		utils.Errs(evsmtp.NewError(evsmtp.RCPTsStage, context.DeadlineExceeded)), nil, ev.SMTPValidatorName)
 
 	type fields struct {
 		validator ev.Validator
@@ -44,6 +47,7 @@ func Test_cacheDecorator_Validate(t *testing.T) {
 		fields     fields
 		args       args
 		wantResult ev.ValidationResult
+		wantHit    bool
 	}{
 		{
 			name: "without cache, error and with set error",
@@ -83,6 +87,26 @@ func Test_cacheDecorator_Validate(t *testing.T) {
 			},
 			wantResult: validResult,
 		},
+		{
+			name: "without cache and with timeout error",
+			fields: fields{
+				validator: &sequenceValidator{results: []func() ev.ValidationResult{func() ev.ValidationResult {
+					return timeoutResult
+				}}},
+				cache: func() evcache.Interface {
+					cacheMock := NewMockInterface(ctrl)
+					cacheMock.EXPECT().Get(ctx, key).Return(nil, nil).Times(1)
+
+					return cacheMock
+				},
+				getKey: ev.EmailCacheKeyGetter,
+			},
+			args: args{
+				email:   validEmail,
+				results: results,
+			},
+			wantResult: timeoutResult,
+		},
 		{
 			name: "with cache",
 			fields: fields{
@@ -100,14 +124,20 @@ func Test_cacheDecorator_Validate(t *testing.T) {
 				results: nil,
 			},
 			wantResult: validResult,
+			wantHit:    true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := ev.NewCacheDecorator(tt.fields.validator, tt.fields.cache(), tt.fields.getKey)
+			var gotHits []bool
+			c.(*ev.CacheDecorator).OnCache = func(_ context.Context, hit bool) {
+				gotHits = append(gotHits, hit)
+			}
 			if gotResult := c.Validate(ev.NewInput(tt.args.email), tt.args.results...); !reflect.DeepEqual(gotResult, tt.wantResult) {
 				t.Errorf("Validate() = %v, want %v", gotResult, tt.wantResult)
 			}
+			require.Equal(t, []bool{tt.wantHit}, gotHits)
 		})
 	}
 }
@@ -232,3 +262,76 @@ func Test_Cache(t *testing.T) {
 	got := *gotInterface.(*ev.ValidationResult)
 	require.Equal(t, validatorResult, got)
 }
+
+func newMarshallerCache(t *testing.T) evcache.Interface {
+	bigCacheClient, err := bigcache.NewBigCache(bigcache.DefaultConfig(time.Minute))
+	require.Nil(t, err)
+
+	return evcache.NewCacheMarshaller(marshaler.New(store.NewBigcache(bigCacheClient)), func() interface{} {
+		return new(ev.ValidationResult)
+	})
+}
+
+func Test_Cache_TypedResults(t *testing.T) {
+	ctx := context.Background()
+	result := validatorResult.(*ev.AValidationResult)
+
+	tests := []struct {
+		name   string
+		result ev.ValidationResult
+	}{
+		{
+			name:   "mx",
+			result: ev.NewMXValidationResult(evsmtp.MXs{{Host: "mx.domain.com.", Pref: 10}}, result),
+		},
+		{
+			name:   "gravatar",
+			result: ev.NewGravatarValidationResult("https://www.gravatar.com/avatar/hash", result),
+		},
+		{
+			name:   "subaddress",
+			result: ev.NewSubaddressValidationResult(evmail.NewEmailAddress("user", "domain.com"), "tag", result),
+		},
+		{
+			name:   "not subaddress",
+			result: ev.NewSubaddressValidationResult(nil, "", result),
+		},
+		{
+			name:   "suggestion",
+			result: ev.NewSuggestionValidationResult("gmail.com", 0.9, result),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newMarshallerCache(t)
+			require.NoError(t, cache.Set(ctx, tt.name, tt.result))
+
+			got := ev.NewCacheDecorator(mockValidator{result: false}, cache, func(ev.Input, ...ev.ValidationResult) interface{} {
+				return tt.name
+			}).Validate(ev.NewInput(validEmail))
+			require.Equal(t, tt.result, got)
+		})
+	}
+}
+
+func Test_Cache_MXBeforeSMTP(t *testing.T) {
+	var lookups int
+	mxs := evsmtp.MXs{{Host: "mx.domain.com.", Pref: 10}}
+	mxValidator := ev.NewCacheDecorator(ev.NewMXValidator(func(string) (evsmtp.MXs, error) {
+		lookups++
+		return mxs, nil
+	}), newMarshallerCache(t), ev.DomainCacheKeyGetter)
+	smtpValidator := ev.NewSMTPValidator(mockChecker{})
+
+	for _, email := range []string{"first@domain.com", "second@domain.com"} {
+		input := ev.NewInput(evmail.FromString(email))
+		syntaxResult := ev.NewSyntaxValidator().Validate(input)
+
+		mxResult := mxValidator.Validate(input, syntaxResult)
+		require.Equal(t, mxs, mxResult.(ev.MXValidationResult).MX())
+
+		got := smtpValidator.Validate(input, syntaxResult, mxResult)
+		require.True(t, got.IsValid())
+	}
+	require.Equal(t, 1, lookups)
+}
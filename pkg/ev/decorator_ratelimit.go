@@ -0,0 +1,304 @@
+package ev
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitKeyGetter returns key of rate limits, empty key is not limited
+type RateLimitKeyGetter func(input Input, results ...ValidationResult) string
+
+// DomainRateLimitKey returns domain of email
+func DomainRateLimitKey(input Input, _ ...ValidationResult) string {
+	return input.Email().Domain()
+}
+
+// MXRateLimitKey returns host of the first MX record from MXValidationResult in results, domain is used if there is no MX.
+// SMTP connects to the first MX record, so validations are limited by mail server.
+func MXRateLimitKey(input Input, results ...ValidationResult) string {
+	for _, result := range results {
+		if mxResult, ok := result.(MXValidationResult); ok && len(mxResult.MX()) > 0 && mxResult.MX()[0] != nil {
+			return strings.ToLower(strings.TrimSuffix(mxResult.MX()[0].Host, "."))
+		}
+	}
+
+	return DomainRateLimitKey(input)
+}
+
+// RateLimit is token bucket and concurrency limits for key
+type RateLimit struct {
+	// Rate is number of validations per second, it is unlimited if 0
+	Rate float64 `json:"rate,omitempty" yaml:"rate,omitempty"`
+	// Burst is size of token bucket, 1 is used if it is 0
+	Burst int `json:"burst,omitempty" yaml:"burst,omitempty"`
+	// Concurrency is maximal number of simultaneous validations, it is unlimited if 0
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+}
+
+// Validate checks values of RateLimit
+func (r RateLimit) Validate() error {
+	switch {
+	case r.Rate < 0:
+		return &ParamsError{Field: "rate", Reason: fmt.Sprintf("negative rate %v", r.Rate)}
+	case r.Burst < 0:
+		return &ParamsError{Field: "burst", Reason: fmt.Sprintf("negative burst %d", r.Burst)}
+	case r.Concurrency < 0:
+		return &ParamsError{Field: "concurrency", Reason: fmt.Sprintf("negative concurrency %d", r.Concurrency)}
+	}
+
+	return nil
+}
+
+// RateLimitDTO is DTO for NewRateLimitDecorator
+type RateLimitDTO struct {
+	// Name is name of result of limited validation, OtherValidator is used if it is empty
+	Name ValidatorName
+	// GetKey is DomainRateLimitKey by default
+	GetKey RateLimitKeyGetter
+	// Default is limit of keys without provider
+	Default RateLimit
+	// Providers are limits by domains, key matches provider if it is equal to provider or is subdomain of it.
+	// Keys of provider share limits, e.g. "google.com" for MX hosts of gmail.com.
+	Providers map[string]RateLimit
+	// Wait waits for limits instead of returning of RateLimitedError
+	Wait bool
+}
+
+// RateLimitedErr is text for RateLimitedError.Error
+const RateLimitedErr = "RateLimitedError"
+
+// RateLimitedError is error of RateLimitDecorator, result with it is OutcomeUnknown
+type RateLimitedError struct {
+	// Key is key of limits, it is provider for keys of provider
+	Key string
+	// Reason is rate, concurrency or error of context
+	Reason string
+}
+
+func (r *RateLimitedError) Error() string {
+	return fmt.Sprintf("%s: %s limit of %q", RateLimitedErr, r.Reason, r.Key)
+}
+
+// Code returns RateLimitedCode
+func (r *RateLimitedError) Code() string {
+	return RateLimitedCode
+}
+
+// Category returns TransientCategory
+func (r *RateLimitedError) Category() string {
+	return TransientCategory
+}
+
+// Details returns key and reason of limit
+func (r *RateLimitedError) Details() map[string]interface{} {
+	return map[string]interface{}{
+		"key":    r.Key,
+		"reason": r.Reason,
+	}
+}
+
+// Reasons of RateLimitedError
+const (
+	RateLimitReasonRate        = "rate"
+	RateLimitReasonConcurrency = "concurrency"
+)
+
+// NewRateLimitDecorator instantiates RateLimitDecorator
+func NewRateLimitDecorator(validator Validator, dto RateLimitDTO) Validator {
+	if dto.Name == "" {
+		dto.Name = OtherValidator
+	}
+	if dto.GetKey == nil {
+		dto.GetKey = DomainRateLimitKey
+	}
+	providers := make(map[string]RateLimit, len(dto.Providers))
+	for provider, limit := range dto.Providers {
+		providers[strings.ToLower(provider)] = limit
+	}
+	dto.Providers = providers
+
+	return &RateLimitDecorator{
+		Validator: validator,
+		dto:       dto,
+		buckets:   make(map[string]*rateLimitBucket),
+	}
+}
+
+// RateLimitDecorator limits validations by keys with token buckets and concurrency limits
+type RateLimitDecorator struct {
+	Validator Validator
+	dto       RateLimitDTO
+	mu        sync.Mutex
+	buckets   map[string]*rateLimitBucket
+}
+
+// rateLimitSweepSize is number of buckets, after which idle buckets are removed
+const rateLimitSweepSize = 10000
+
+type rateLimitBucket struct {
+	mu     sync.Mutex
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+	slots  chan struct{}
+}
+
+func (r *RateLimitDecorator) GetDeps() []ValidatorName {
+	return r.Validator.GetDeps()
+}
+
+func (r *RateLimitDecorator) Validate(input Input, results ...ValidationResult) ValidationResult {
+	return r.ValidateContext(context.Background(), input, results...)
+}
+
+func (r *RateLimitDecorator) ValidateContext(ctx context.Context, input Input, results ...ValidationResult) ValidationResult {
+	key, limit := r.limit(r.dto.GetKey(input, results...))
+	if key == "" || (limit.Rate == 0 && limit.Concurrency == 0) {
+		return ValidateContext(ctx, r.Validator, input, results...)
+	}
+
+	bucket := r.bucket(key, limit)
+	release, reason := bucket.acquire(ctx, r.dto.Wait)
+	if reason != "" {
+		return NewResultWithOutcome(OutcomeUnknown, []error{&RateLimitedError{Key: key, Reason: reason}}, nil, r.dto.Name)
+	}
+	defer release()
+
+	return ValidateContext(ctx, r.Validator, input, results...)
+}
+
+// limit returns key of bucket and its limit, the longest matched provider is used
+func (r *RateLimitDecorator) limit(key string) (string, RateLimit) {
+	key = strings.ToLower(key)
+	bucketKey, bucketLimit := key, r.dto.Default
+	matched := ""
+	for provider, limit := range r.dto.Providers {
+		if (key == provider || strings.HasSuffix(key, "."+provider)) && len(provider) > len(matched) {
+			matched, bucketKey, bucketLimit = provider, provider, limit
+		}
+	}
+
+	return bucketKey, bucketLimit
+}
+
+func (r *RateLimitDecorator) bucket(key string, limit RateLimit) *rateLimitBucket {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if bucket, ok := r.buckets[key]; ok {
+		return bucket
+	}
+
+	if len(r.buckets) >= rateLimitSweepSize {
+		for bucketKey, bucket := range r.buckets {
+			if bucket.idle() {
+				delete(r.buckets, bucketKey)
+			}
+		}
+	}
+
+	bucket := &rateLimitBucket{limit: limit, tokens: float64(limit.burst()), last: time.Now()}
+	if limit.Concurrency > 0 {
+		bucket.slots = make(chan struct{}, limit.Concurrency)
+	}
+	r.buckets[key] = bucket
+
+	return bucket
+}
+
+func (r RateLimit) burst() int {
+	if r.Burst == 0 {
+		return 1
+	}
+
+	return r.Burst
+}
+
+// acquire takes slot and token, reason is not empty if limits are exceeded or ctx is done during waiting
+func (b *rateLimitBucket) acquire(ctx context.Context, wait bool) (release func(), reason string) {
+	release = func() {}
+	if b.slots != nil {
+		select {
+		case b.slots <- struct{}{}:
+		default:
+			if !wait {
+				return release, RateLimitReasonConcurrency
+			}
+			select {
+			case b.slots <- struct{}{}:
+			case <-ctx.Done():
+				return release, ctx.Err().Error()
+			}
+		}
+		release = func() {
+			<-b.slots
+		}
+	}
+
+	if b.limit.Rate == 0 {
+		return release, ""
+	}
+
+	delay, ok := b.reserve(wait)
+	if !ok {
+		release()
+		return func() {}, RateLimitReasonRate
+	}
+	if delay > 0 && !sleepContext(ctx, delay) {
+		b.cancel()
+		release()
+		return func() {}, ctx.Err().Error()
+	}
+
+	return release, ""
+}
+
+// reserve takes token, delay is time to wait for the token if wait is true
+func (b *rateLimitBucket) reserve(wait bool) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	if b.tokens < 1 && !wait {
+		return 0, false
+	}
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0, true
+	}
+
+	return time.Duration(-b.tokens / b.limit.Rate * float64(time.Second)), true
+}
+
+// cancel returns reserved token
+func (b *rateLimitBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+}
+
+func (b *rateLimitBucket) refill() {
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+	if burst := float64(b.limit.burst()); b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+}
+
+// idle is true if bucket is full and there are no validations
+func (b *rateLimitBucket) idle() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit.Rate > 0 {
+		b.refill()
+	}
+
+	return len(b.slots) == 0 && (b.limit.Rate == 0 || b.tokens >= float64(b.limit.burst()))
+}
@@ -0,0 +1,191 @@
+package ev_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingValidator struct {
+	ev.AValidatorWithoutDeps
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingValidator) Validate(_ ev.Input, _ ...ev.ValidationResult) ev.ValidationResult {
+	b.started <- struct{}{}
+	<-b.release
+
+	return ev.NewValidResult(ev.SMTPValidatorName)
+}
+
+func rateLimitedReason(t *testing.T, result ev.ValidationResult) string {
+	require.Equal(t, ev.OutcomeUnknown, result.Outcome())
+	require.Len(t, result.Errors(), 1)
+
+	var rateLimitedErr *ev.RateLimitedError
+	require.True(t, errors.As(result.Errors()[0], &rateLimitedErr))
+
+	return rateLimitedErr.Reason
+}
+
+func TestMXRateLimitKey(t *testing.T) {
+	input := ev.NewInput(evmail.FromString("user@gmail.com"))
+	mxResult := ev.NewMXValidationResult([]*net.MX{{Host: "Alt1.Gmail-SMTP-In.L.Google.com."}}, ev.NewValidResult(ev.MXValidatorName).(*ev.AValidationResult))
+
+	require.Equal(t, "alt1.gmail-smtp-in.l.google.com", ev.MXRateLimitKey(input, ev.NewValidResult(ev.SyntaxValidatorName), mxResult))
+	require.Equal(t, "gmail.com", ev.MXRateLimitKey(input))
+	require.Equal(t, "gmail.com", ev.DomainRateLimitKey(input, mxResult))
+}
+
+func TestRateLimit_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     ev.RateLimit
+		wantField string
+	}{
+		{name: "unlimited", limit: ev.RateLimit{}},
+		{name: "valid", limit: ev.RateLimit{Rate: 0.5, Burst: 2, Concurrency: 1}},
+		{name: "negative rate", limit: ev.RateLimit{Rate: -1}, wantField: "rate"},
+		{name: "negative burst", limit: ev.RateLimit{Burst: -1}, wantField: "burst"},
+		{name: "negative concurrency", limit: ev.RateLimit{Concurrency: -1}, wantField: "concurrency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limit.Validate()
+			if tt.wantField == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var paramsErr *ev.ParamsError
+			require.True(t, errors.As(err, &paramsErr))
+			require.Equal(t, tt.wantField, paramsErr.Field)
+		})
+	}
+}
+
+func TestRateLimitDecorator_Validate_Rate(t *testing.T) {
+	validator := ev.NewRateLimitDecorator(&sequenceValidator{results: []func() ev.ValidationResult{retryValidResult}}, ev.RateLimitDTO{
+		Name:    ev.SMTPValidatorName,
+		Default: ev.RateLimit{Rate: 0.001, Burst: 2},
+	})
+
+	for i := 0; i < 2; i++ {
+		require.True(t, validator.Validate(ev.NewInput(evmail.FromString("user@domain.com"))).IsValid())
+	}
+
+	got := validator.Validate(ev.NewInput(evmail.FromString("user@domain.com")))
+	require.Equal(t, ev.SMTPValidatorName, got.ValidatorName())
+	require.Equal(t, ev.RateLimitReasonRate, rateLimitedReason(t, got))
+
+	// other keys have own buckets
+	require.True(t, validator.Validate(ev.NewInput(evmail.FromString("user@other.com"))).IsValid())
+}
+
+func TestRateLimitDecorator_Validate_Providers(t *testing.T) {
+	validator := ev.NewRateLimitDecorator(&sequenceValidator{results: []func() ev.ValidationResult{retryValidResult}}, ev.RateLimitDTO{
+		GetKey: ev.MXRateLimitKey,
+		Providers: map[string]ev.RateLimit{
+			"Google.com":   {Rate: 0.001},
+			"l.google.com": {Rate: 0.001, Burst: 2},
+		},
+	})
+	mxResult := func(host string) ev.ValidationResult {
+		return ev.NewMXValidationResult([]*net.MX{{Host: host}}, ev.NewValidResult(ev.MXValidatorName).(*ev.AValidationResult))
+	}
+	input := ev.NewInput(evmail.FromString("user@gmail.com"))
+
+	// hosts of the longest provider share bucket
+	require.True(t, validator.Validate(input, mxResult("alt1.gmail-smtp-in.l.google.com.")).IsValid())
+	require.True(t, validator.Validate(input, mxResult("alt2.gmail-smtp-in.l.google.com.")).IsValid())
+	got := validator.Validate(input, mxResult("gmail-smtp-in.l.google.com."))
+	require.Equal(t, ev.RateLimitReasonRate, rateLimitedReason(t, got))
+	require.Equal(t, ev.OtherValidator, got.ValidatorName())
+	require.Equal(t, `RateLimitedError: rate limit of "l.google.com"`, got.Errors()[0].Error())
+
+	require.True(t, validator.Validate(input, mxResult("aspmx.google.com.")).IsValid())
+	require.False(t, validator.Validate(input, mxResult("google.com.")).IsValid())
+
+	// keys without provider are not limited by default
+	for i := 0; i < 3; i++ {
+		require.True(t, validator.Validate(input, mxResult("mx.notgoogle.com.")).IsValid())
+	}
+}
+
+func TestRateLimitDecorator_Validate_Concurrency(t *testing.T) {
+	tests := []struct {
+		name       string
+		wait       bool
+		wantReason string
+	}{
+		{name: "without waiting", wait: false, wantReason: ev.RateLimitReasonConcurrency},
+		{name: "with waiting", wait: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocking := &blockingValidator{started: make(chan struct{}), release: make(chan struct{})}
+			validator := ev.NewRateLimitDecorator(blocking, ev.RateLimitDTO{
+				Default: ev.RateLimit{Concurrency: 1},
+				Wait:    tt.wait,
+			})
+			input := ev.NewInput(evmail.FromString("user@domain.com"))
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				validator.Validate(input)
+			}()
+			<-blocking.started
+
+			if !tt.wait {
+				require.Equal(t, tt.wantReason, rateLimitedReason(t, validator.Validate(input)))
+				close(blocking.release)
+				wg.Wait()
+				return
+			}
+
+			done := make(chan ev.ValidationResult)
+			go func() {
+				done <- validator.Validate(input)
+			}()
+			select {
+			case <-blocking.started:
+				t.Fatal("concurrency limit is exceeded")
+			case <-time.After(20 * time.Millisecond):
+			}
+
+			close(blocking.release)
+			<-blocking.started
+			require.True(t, (<-done).IsValid())
+			wg.Wait()
+		})
+	}
+}
+
+func TestRateLimitDecorator_ValidateContext_Wait(t *testing.T) {
+	validator := ev.NewRateLimitDecorator(&sequenceValidator{results: []func() ev.ValidationResult{retryValidResult}}, ev.RateLimitDTO{
+		Default: ev.RateLimit{Rate: 50},
+		Wait:    true,
+	})
+	input := ev.NewInput(evmail.FromString("user@domain.com"))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.True(t, ev.ValidateContext(context.Background(), validator, input).IsValid())
+	}
+	require.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got := ev.ValidateContext(ctx, validator, input)
+	require.Equal(t, context.Canceled.Error(), rateLimitedReason(t, got))
+}
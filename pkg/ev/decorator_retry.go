@@ -0,0 +1,342 @@
+package ev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+)
+
+// IsRetryable detects transient errors, validator is retried if result has such error
+type IsRetryable func(err error) bool
+
+// DefaultRetryStages are stages of evsmtp.Error, which are retried by DefaultIsRetryable
+var DefaultRetryStages = []evsmtp.SendMailStage{
+	evsmtp.ConnectionStage,
+	evsmtp.ClientStage,
+	evsmtp.HelloStage,
+	evsmtp.AuthStage,
+	evsmtp.MailStage,
+	evsmtp.RCPTsStage,
+}
+
+// DefaultIsRetryable is NewIsRetryable with DefaultRetryStages
+var DefaultIsRetryable = NewIsRetryable(DefaultRetryStages...)
+
+// NewIsRetryable returns IsRetryable, which is true for transient evsmtp.Error on stages (see evsmtp.IsTransient),
+// temporary or timeout *net.DNSError, errors of Gravatar requests and RateLimitedError.
+// Cancellation of context is not retryable.
+func NewIsRetryable(stages ...evsmtp.SendMailStage) IsRetryable {
+	return func(err error) bool {
+		if err == nil || errors.Is(err, context.Canceled) {
+			return false
+		}
+
+		var smtpErr evsmtp.Error
+		if errors.As(err, &smtpErr) {
+			for _, stage := range stages {
+				if smtpErr.Stage() == stage {
+					return evsmtp.IsTransient(err)
+				}
+			}
+			return false
+		}
+
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) {
+			return dnsErr.IsTemporary || dnsErr.IsTimeout
+		}
+
+		var urlErr *url.Error
+		var gravatarErr GravatarError
+		var rateLimitedErr *RateLimitedError
+		return errors.As(err, &urlErr) || errors.As(err, &gravatarErr) || errors.As(err, &rateLimitedErr)
+	}
+}
+
+// Default values of RetryPolicy
+const (
+	DefaultRetryAttempts   = 3
+	DefaultRetryDelay      = time.Second
+	DefaultRetryMultiplier = 2
+	DefaultRetryMaxDelay   = 10 * time.Second
+	DefaultRetryJitter     = 0.2
+)
+
+// DefaultRetryPolicy returns RetryPolicy with default values
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Attempts:   DefaultRetryAttempts,
+		Delay:      DefaultRetryDelay,
+		Multiplier: DefaultRetryMultiplier,
+		MaxDelay:   DefaultRetryMaxDelay,
+		Jitter:     DefaultRetryJitter,
+	}
+}
+
+// RetryPolicy is exponential backoff of RetryDecorator
+type RetryPolicy struct {
+	// Attempts is maximal number of attempts including the first one, DefaultRetryAttempts is used if it is 0
+	Attempts int `json:"attempts,omitempty" yaml:"attempts,omitempty"`
+	// Delay is delay before the second attempt
+	Delay time.Duration `json:"delay,omitempty" yaml:"delay,omitempty"`
+	// Multiplier increases delay for each next attempt, 1 is used if it is less than 1
+	Multiplier float64 `json:"multiplier,omitempty" yaml:"multiplier,omitempty"`
+	// MaxDelay limits delay if it is positive
+	MaxDelay time.Duration `json:"maxDelay,omitempty" yaml:"maxDelay,omitempty"`
+	// Jitter changes delay randomly by the fraction of delay, from 0 to 1
+	Jitter float64 `json:"jitter,omitempty" yaml:"jitter,omitempty"`
+	// IsRetryable is DefaultIsRetryable if nil
+	IsRetryable IsRetryable `json:"-" yaml:"-"`
+}
+
+// Validate checks values of RetryPolicy
+func (r RetryPolicy) Validate() error {
+	switch {
+	case r.Attempts < 0:
+		return &ParamsError{Field: "attempts", Reason: fmt.Sprintf("negative number %d", r.Attempts)}
+	case r.Delay < 0:
+		return &ParamsError{Field: "delay", Reason: fmt.Sprintf("negative duration %v", r.Delay)}
+	case r.MaxDelay < 0:
+		return &ParamsError{Field: "maxDelay", Reason: fmt.Sprintf("negative duration %v", r.MaxDelay)}
+	case r.Jitter < 0 || r.Jitter > 1:
+		return &ParamsError{Field: "jitter", Reason: fmt.Sprintf("%v is out of range from 0 to 1", r.Jitter)}
+	}
+
+	return nil
+}
+
+// Backoff returns delay after attempt, attempts start from 1
+func (r RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := r.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(r.Delay) * math.Pow(multiplier, float64(attempt-1))
+	if r.MaxDelay > 0 && delay > float64(r.MaxDelay) {
+		delay = float64(r.MaxDelay)
+	}
+	if r.Jitter > 0 {
+		delay += delay * r.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// RetryAttempt is attempt of RetryDecorator
+type RetryAttempt struct {
+	Attempt  int           `json:"attempt"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Outcome  Outcome       `json:"outcome"`
+	// Errors are texts of errors of the attempt
+	Errors []string `json:"errors,omitempty"`
+	// Delay is waiting before the next attempt
+	Delay time.Duration `json:"delay,omitempty"`
+}
+
+// RetryErr is text for RetryError.Error
+const RetryErr = "RetryError"
+
+// RetryError is warning of RetryDecorator with history of attempts, it is added if validator was retried
+type RetryError struct {
+	Attempts []RetryAttempt
+}
+
+func (r *RetryError) Error() string {
+	return fmt.Sprintf("%s: %d attempts", RetryErr, len(r.Attempts))
+}
+
+// Code returns RetryCode
+func (r *RetryError) Code() string {
+	return RetryCode
+}
+
+// Category returns TransientCategory
+func (r *RetryError) Category() string {
+	return TransientCategory
+}
+
+// Details returns number of attempts
+func (r *RetryError) Details() map[string]interface{} {
+	return map[string]interface{}{"attempts": len(r.Attempts)}
+}
+
+// RetryAttempts returns history of attempts from RetryError in warnings of result
+func RetryAttempts(result ValidationResult) []RetryAttempt {
+	if result == nil {
+		return nil
+	}
+
+	for _, warning := range result.Warnings() {
+		var retryErr *RetryError
+		if errors.As(warning, &retryErr) {
+			return retryErr.Attempts
+		}
+	}
+
+	return nil
+}
+
+// NewRetryDecorator instantiates RetryDecorator.
+// Validator is retried while outcome of result is OutcomeUnknown and any of errors is retryable by policy.
+func NewRetryDecorator(validator Validator, policy RetryPolicy) Validator {
+	if policy.Attempts == 0 {
+		policy.Attempts = DefaultRetryAttempts
+	}
+	if policy.IsRetryable == nil {
+		policy.IsRetryable = DefaultIsRetryable
+	}
+
+	return &RetryDecorator{
+		Validator: validator,
+		Policy:    policy,
+	}
+}
+
+// RetryDecorator retries Validator with backoff, RetryError with history is added to warnings of retried result
+type RetryDecorator struct {
+	Validator Validator
+	Policy    RetryPolicy
+}
+
+func (r *RetryDecorator) GetDeps() []ValidatorName {
+	return r.Validator.GetDeps()
+}
+
+func (r *RetryDecorator) Validate(input Input, results ...ValidationResult) ValidationResult {
+	return r.ValidateContext(context.Background(), input, results...)
+}
+
+func (r *RetryDecorator) ValidateContext(ctx context.Context, input Input, results ...ValidationResult) ValidationResult {
+	var result ValidationResult
+	var attempts []RetryAttempt
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		result = ValidateContext(ctx, r.Validator, input, results...)
+		attempts = append(attempts, RetryAttempt{
+			Attempt:  attempt,
+			Start:    start,
+			Duration: time.Since(start),
+			Outcome:  result.Outcome(),
+			Errors:   errorsText(result.Errors()),
+		})
+
+		if attempt >= r.Policy.Attempts || !r.isRetryable(result) || ctx.Err() != nil {
+			break
+		}
+
+		delay := r.Policy.Backoff(attempt)
+		attempts[len(attempts)-1].Delay = delay
+		if !sleepContext(ctx, delay) {
+			break
+		}
+	}
+
+	// history is added if validator was retried or waiting for retry was interrupted
+	if len(attempts) > 1 || attempts[0].Delay > 0 {
+		warnings := append(append([]error{}, result.Warnings()...), &RetryError{Attempts: attempts})
+		result = withWarnings(result, warnings)
+	}
+
+	return result
+}
+
+// withWarnings returns copy of result with warnings, the result is not changed, because it can be shared by caches.
+// Types of results of the package are kept, results of unknown types are wrapped by WarningsResult.
+func withWarnings(result ValidationResult, warnings []error) ValidationResult {
+	switch r := result.(type) {
+	case *AValidationResult:
+		return copyWithWarnings(r, warnings)
+	case mxValidationResult:
+		r.AValidationResult = copyWithWarnings(r.AValidationResult, warnings)
+		return r
+	case gravatarValidationResult:
+		r.AValidationResult = copyWithWarnings(r.AValidationResult, warnings)
+		return r
+	case subaddressValidationResult:
+		r.AValidationResult = copyWithWarnings(r.AValidationResult, warnings)
+		return r
+	case suggestionValidationResult:
+		r.AValidationResult = copyWithWarnings(r.AValidationResult, warnings)
+		return r
+	}
+
+	return WarningsResult{ValidationResult: result, WarningsVal: warnings}
+}
+
+// WarningsResult replaces warnings of ValidationResult, it is used by RetryDecorator for results of unknown types
+type WarningsResult struct {
+	ValidationResult
+	WarningsVal []error
+}
+
+// Warnings returns warnings of the result with RetryError
+func (w WarningsResult) Warnings() []error {
+	return w.WarningsVal
+}
+
+// HasWarnings checks for the presence of the Warnings
+func (w WarningsResult) HasWarnings() bool {
+	return len(w.WarningsVal) > 0
+}
+
+// Unwrap returns the wrapped result, e.g. to get methods of its type
+func (w WarningsResult) Unwrap() ValidationResult {
+	return w.ValidationResult
+}
+
+func copyWithWarnings(result *AValidationResult, warnings []error) *AValidationResult {
+	resultCopy := *result
+	resultCopy.WarningsVal = warnings
+
+	return &resultCopy
+}
+
+func (r *RetryDecorator) isRetryable(result ValidationResult) bool {
+	if result.Outcome() != OutcomeUnknown {
+		return false
+	}
+
+	for _, err := range result.Errors() {
+		if r.Policy.IsRetryable(err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sleepContext waits for delay, false is returned if ctx is done earlier
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func errorsText(errs []error) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	texts := make([]string, len(errs))
+	for i, err := range errs {
+		texts[i] = err.Error()
+	}
+
+	return texts
+}
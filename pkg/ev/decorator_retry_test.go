@@ -0,0 +1,250 @@
+package ev_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/allegro/bigcache"
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evcache"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/gocache/marshaler"
+	"github.com/prodadidb/gocache/store"
+	"github.com/stretchr/testify/require"
+)
+
+type sequenceValidator struct {
+	ev.AValidatorWithoutDeps
+	results []func() ev.ValidationResult
+	calls   int
+}
+
+func (s *sequenceValidator) Validate(_ ev.Input, _ ...ev.ValidationResult) ev.ValidationResult {
+	result := s.results[s.calls]
+	if s.calls < len(s.results)-1 {
+		s.calls++
+	}
+
+	return result()
+}
+
+var (
+	retryTransientErr = evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 451, Msg: "try later"})
+	retryPermanentErr = evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "user unknown"})
+)
+
+func retryUnknownResult() ev.ValidationResult {
+	return ev.NewResultWithOutcome(ev.OutcomeUnknown, []error{retryTransientErr}, nil, ev.SMTPValidatorName)
+}
+
+func retryInvalidResult() ev.ValidationResult {
+	return ev.NewResult(false, []error{retryPermanentErr}, nil, ev.SMTPValidatorName)
+}
+
+func retryValidResult() ev.ValidationResult {
+	return ev.NewValidResult(ev.SMTPValidatorName)
+}
+
+func TestNewIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "connection", err: evsmtp.ErrConnection, want: true},
+		{name: "rcpt 4xx", err: retryTransientErr, want: true},
+		{name: "rcpt 5xx", err: retryPermanentErr, want: false},
+		{name: "random rcpt 4xx", err: evsmtp.NewError(evsmtp.RandomRCPTStage, &textproto.Error{Code: 451}), want: false},
+		{name: "dns temporary", err: &net.DNSError{IsTemporary: true}, want: true},
+		{name: "dns not found", err: &net.DNSError{IsNotFound: true}, want: false},
+		{name: "gravatar network", err: &url.Error{Op: "Head", Err: errors.New("connection refused")}, want: true},
+		{name: "gravatar canceled", err: &url.Error{Op: "Head", Err: context.Canceled}, want: false},
+		{name: "gravatar status", err: ev.GravatarError{}, want: true},
+		{name: "rate limited", err: &ev.RateLimitedError{Key: "domain.com", Reason: ev.RateLimitReasonRate}, want: true},
+		{name: "syntax", err: ev.SyntaxError{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ev.DefaultIsRetryable(tt.err))
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := ev.RetryPolicy{Delay: 10 * time.Millisecond, Multiplier: 2, MaxDelay: 30 * time.Millisecond}
+	for attempt, want := range []time.Duration{10, 20, 30, 30} {
+		require.Equal(t, want*time.Millisecond, policy.Backoff(attempt+1))
+	}
+
+	policy = ev.RetryPolicy{Delay: 100 * time.Millisecond, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		got := policy.Backoff(3)
+		require.GreaterOrEqual(t, got, 50*time.Millisecond)
+		require.LessOrEqual(t, got, 150*time.Millisecond)
+	}
+}
+
+func TestRetryPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    ev.RetryPolicy
+		wantField string
+	}{
+		{name: "default", policy: ev.DefaultRetryPolicy()},
+		{name: "attempts", policy: ev.RetryPolicy{Attempts: -1}, wantField: "attempts"},
+		{name: "delay", policy: ev.RetryPolicy{Delay: -1}, wantField: "delay"},
+		{name: "max delay", policy: ev.RetryPolicy{MaxDelay: -1}, wantField: "maxDelay"},
+		{name: "jitter", policy: ev.RetryPolicy{Jitter: 1.5}, wantField: "jitter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantField == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var paramsErr *ev.ParamsError
+			require.True(t, errors.As(err, &paramsErr))
+			require.Equal(t, tt.wantField, paramsErr.Field)
+		})
+	}
+}
+
+func TestRetryDecorator_Validate(t *testing.T) {
+	policy := ev.RetryPolicy{Attempts: 3, Delay: time.Millisecond}
+
+	tests := []struct {
+		name         string
+		results      []func() ev.ValidationResult
+		wantOutcome  ev.Outcome
+		wantAttempts int
+	}{
+		{
+			name:         "valid",
+			results:      []func() ev.ValidationResult{retryValidResult},
+			wantOutcome:  ev.OutcomeValid,
+			wantAttempts: 0,
+		},
+		{
+			name:         "invalid",
+			results:      []func() ev.ValidationResult{retryInvalidResult},
+			wantOutcome:  ev.OutcomeInvalid,
+			wantAttempts: 0,
+		},
+		{
+			name:         "valid after retries",
+			results:      []func() ev.ValidationResult{retryUnknownResult, retryUnknownResult, retryValidResult},
+			wantOutcome:  ev.OutcomeValid,
+			wantAttempts: 3,
+		},
+		{
+			name:         "invalid after retry",
+			results:      []func() ev.ValidationResult{retryUnknownResult, retryInvalidResult},
+			wantOutcome:  ev.OutcomeInvalid,
+			wantAttempts: 2,
+		},
+		{
+			name:         "attempts are exhausted",
+			results:      []func() ev.ValidationResult{retryUnknownResult},
+			wantOutcome:  ev.OutcomeUnknown,
+			wantAttempts: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &sequenceValidator{results: tt.results}
+
+			got := ev.NewRetryDecorator(validator, policy).Validate(ev.NewInput(evmail.FromString("user@domain.com")))
+
+			require.Equal(t, tt.wantOutcome, got.Outcome())
+			attempts := ev.RetryAttempts(got)
+			require.Len(t, attempts, tt.wantAttempts)
+			for i, attempt := range attempts {
+				require.Equal(t, i+1, attempt.Attempt)
+				require.Equal(t, i < len(attempts)-1, attempt.Delay > 0)
+			}
+		})
+	}
+}
+
+func TestRetryDecorator_ValidateContext_Canceled(t *testing.T) {
+	validator := &sequenceValidator{results: []func() ev.ValidationResult{retryUnknownResult, retryValidResult}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	decorator := ev.NewRetryDecorator(validator, ev.RetryPolicy{Attempts: 2, Delay: time.Minute})
+	got := ev.ValidateContext(ctx, decorator, ev.NewInput(evmail.FromString("user@domain.com")))
+
+	require.Equal(t, ev.OutcomeUnknown, got.Outcome())
+	require.Len(t, ev.RetryAttempts(got), 1)
+}
+
+func TestRetryDecorator_Validate_SharedResult(t *testing.T) {
+	mxs := evsmtp.MXs{{Host: "mx.domain.com.", Pref: 10}}
+	shared := ev.NewMXValidationResult(mxs, ev.NewResultWithOutcome(
+		ev.OutcomeUnknown, []error{&net.DNSError{IsTemporary: true}}, nil, ev.MXValidatorName,
+	).(*ev.AValidationResult))
+	validator := &sequenceValidator{results: []func() ev.ValidationResult{func() ev.ValidationResult {
+		return shared
+	}}}
+
+	decorator := ev.NewRetryDecorator(validator, ev.RetryPolicy{Attempts: 2, Delay: time.Millisecond})
+	for i := 0; i < 2; i++ {
+		got := decorator.Validate(ev.NewInput(evmail.FromString("user@domain.com")))
+
+		require.Equal(t, mxs, got.(ev.MXValidationResult).MX())
+		require.Len(t, got.Warnings(), 1)
+		require.Len(t, ev.RetryAttempts(got), 2)
+	}
+	require.Empty(t, shared.Warnings(), "shared result is not changed")
+}
+
+// customResult is result of type, which is unknown for RetryDecorator
+type customResult struct {
+	ev.ValidationResult
+}
+
+func TestRetryDecorator_Validate_CustomResult(t *testing.T) {
+	shared := customResult{retryUnknownResult()}
+	validator := &sequenceValidator{results: []func() ev.ValidationResult{func() ev.ValidationResult {
+		return shared
+	}}}
+
+	got := ev.NewRetryDecorator(validator, ev.RetryPolicy{Attempts: 2, Delay: time.Millisecond}).
+		Validate(ev.NewInput(evmail.FromString("user@domain.com")))
+
+	require.Len(t, ev.RetryAttempts(got), 2)
+	require.True(t, got.HasWarnings())
+	require.Equal(t, shared.Errors(), got.Errors())
+	require.Equal(t, shared, got.(ev.WarningsResult).Unwrap())
+	require.Empty(t, shared.Warnings(), "shared result is not changed")
+}
+
+func TestRetryError_Cache(t *testing.T) {
+	bigCacheClient, err := bigcache.NewBigCache(bigcache.DefaultConfig(time.Minute))
+	require.NoError(t, err)
+	cache := evcache.NewCacheMarshaller(marshaler.New(store.NewBigcache(bigCacheClient)), func() interface{} {
+		return new(ev.ValidationResult)
+	})
+
+	validator := &sequenceValidator{results: []func() ev.ValidationResult{retryUnknownResult, retryValidResult}}
+	result := ev.NewRetryDecorator(validator, ev.RetryPolicy{Delay: time.Millisecond}).Validate(nil)
+
+	ctx := context.Background()
+	require.NoError(t, cache.Set(ctx, "key", result))
+	got, err := cache.Get(ctx, "key")
+	require.NoError(t, err)
+
+	attempts := ev.RetryAttempts(*got.(*ev.ValidationResult))
+	require.Len(t, attempts, 2)
+	require.Equal(t, []string{retryTransientErr.Error()}, attempts[0].Errors)
+	require.Equal(t, ev.OutcomeValid, attempts[1].Outcome)
+}
@@ -1,6 +1,8 @@
 package ev
 
 import (
+	"context"
+
 	"github.com/emirpasic/gods/sets"
 )
 
@@ -30,7 +32,11 @@ func (w warningsDecorator) GetDeps() []ValidatorName {
 }
 
 func (w warningsDecorator) Validate(input Input, results ...ValidationResult) ValidationResult {
-	result := w.validator.Validate(input, results...)
+	return w.ValidateContext(context.Background(), input, results...)
+}
+
+func (w warningsDecorator) ValidateContext(ctx context.Context, input Input, results ...ValidationResult) ValidationResult {
+	result := ValidateContext(ctx, w.validator, input, results...)
 	changeableResult, ok := result.(ChangeableValidationResult)
 	if !ok {
 		return result
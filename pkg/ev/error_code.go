@@ -0,0 +1,120 @@
+package ev
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+)
+
+// Categories of CodedError
+const (
+	// SyntaxCategory is category of malformed emails
+	SyntaxCategory = "syntax"
+	// DomainCategory is category of domains, which can not receive emails
+	DomainCategory = "domain"
+	// MailboxCategory is category of rejected or not existing mailboxes
+	MailboxCategory = "mailbox"
+	// PolicyCategory is category of emails rejected by rules, e.g. disposable or role emails
+	PolicyCategory = "policy"
+	// TransientCategory is category of errors, after which validation can succeed later
+	TransientCategory = "transient"
+)
+
+// Codes of CodedError, they are stable and are not changed between versions.
+// Codes of evsmtp.Error are returned by evsmtp.StageCode.
+const (
+	SyntaxCode           = "syntax_invalid"
+	EmptyMXsCode         = "mx_not_found"
+	DNSCode              = "dns_failed"
+	DisposableCode       = "disposable_domain"
+	FreeCode             = "free_domain"
+	RoleCode             = "role_mailbox"
+	BanWordsUsernameCode = "banned_username"
+	BlackListDomainsCode = "blacklisted_domain"
+	BlackListEmailsCode  = "blacklisted_email"
+	WhiteListCode        = "not_whitelisted_domain"
+	GravatarCode         = "gravatar_not_found"
+	DepsCode             = "dependency_failed"
+	SkippedCode          = "skipped"
+	NotApplicableCode    = "not_applicable"
+	TimeoutCode          = "timeout"
+	CanceledCode         = "canceled"
+	PanicCode            = "panic"
+	RetryCode            = "retried"
+	RateLimitedCode      = "rate_limited"
+	SubaddressCode       = "subaddress"
+	SuggestionCode       = "domain_typo"
+	UnknownCode          = "unknown"
+)
+
+// CodedError is error with stable machine-readable code, category and details.
+// All errors of built-in validators and evsmtp.Error implement it.
+type CodedError interface {
+	error
+	// Code is stable code of error, e.g. RoleCode
+	Code() string
+	// Category is one of SyntaxCategory, DomainCategory, MailboxCategory, PolicyCategory or TransientCategory
+	Category() string
+	// Details are structured data of error, e.g. stage and reply code of SMTP, it can be nil
+	Details() map[string]interface{}
+}
+
+// ToCodedError returns the first CodedError in the chain of err.
+// Other errors are wrapped by UncodedError, nil is returned for nil err.
+func ToCodedError(err error) CodedError {
+	if err == nil {
+		return nil
+	}
+
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded
+	}
+
+	return &UncodedError{Err: err}
+}
+
+// UncodedError is CodedError for errors without code, e.g. errors of DNS lookup or context
+type UncodedError struct {
+	Err error
+}
+
+func (u *UncodedError) Error() string {
+	return u.Err.Error()
+}
+
+func (u *UncodedError) Unwrap() error {
+	return u.Err
+}
+
+// Code returns CanceledCode, TimeoutCode, DNSCode or UnknownCode
+func (u *UncodedError) Code() string {
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(u.Err, context.Canceled):
+		return CanceledCode
+	case errors.Is(u.Err, context.DeadlineExceeded):
+		return TimeoutCode
+	case errors.As(u.Err, &dnsErr):
+		return DNSCode
+	}
+
+	return UnknownCode
+}
+
+// Category returns DomainCategory for not found domains, otherwise TransientCategory
+func (u *UncodedError) Category() string {
+	var dnsErr *net.DNSError
+	if errors.As(u.Err, &dnsErr) && !evsmtp.IsTransient(u.Err) {
+		return DomainCategory
+	}
+
+	return TransientCategory
+}
+
+// Details returns nil
+func (u *UncodedError) Details() map[string]interface{} {
+	return nil
+}
@@ -0,0 +1,127 @@
+package ev_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodedError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantCode     string
+		wantCategory string
+		wantDetails  map[string]interface{}
+	}{
+		{name: "syntax", err: ev.SyntaxError{}, wantCode: ev.SyntaxCode, wantCategory: ev.SyntaxCategory},
+		{name: "empty mxs", err: ev.EmptyMXsError{}, wantCode: ev.EmptyMXsCode, wantCategory: ev.DomainCategory},
+		{name: "disposable", err: ev.DisposableError{}, wantCode: ev.DisposableCode, wantCategory: ev.PolicyCategory},
+		{name: "free", err: ev.FreeError{}, wantCode: ev.FreeCode, wantCategory: ev.PolicyCategory},
+		{name: "role", err: ev.RoleError{}, wantCode: ev.RoleCode, wantCategory: ev.PolicyCategory},
+		{name: "ban words", err: ev.BanWordsUsernameError{}, wantCode: ev.BanWordsUsernameCode, wantCategory: ev.PolicyCategory},
+		{name: "blacklist domains", err: ev.BlackListDomainsError{}, wantCode: ev.BlackListDomainsCode, wantCategory: ev.PolicyCategory},
+		{name: "blacklist emails", err: ev.BlackListEmailsError{}, wantCode: ev.BlackListEmailsCode, wantCategory: ev.PolicyCategory},
+		{name: "whitelist", err: ev.WhiteListError{}, wantCode: ev.WhiteListCode, wantCategory: ev.PolicyCategory},
+		{name: "gravatar", err: ev.GravatarError{}, wantCode: ev.GravatarCode, wantCategory: ev.MailboxCategory},
+		{name: "not applicable", err: ev.NotApplicableError{}, wantCode: ev.NotApplicableCode, wantCategory: ev.PolicyCategory},
+		{name: "deps", err: ev.NewDepsError(), wantCode: ev.DepsCode, wantCategory: ev.PolicyCategory},
+		{
+			name:         "panic",
+			err:          ev.NewPanicError(ev.SMTPValidatorName, "boom", nil),
+			wantCode:     ev.PanicCode,
+			wantCategory: ev.TransientCategory,
+			wantDetails:  map[string]interface{}{"validator": string(ev.SMTPValidatorName), "value": "boom"},
+		},
+		{
+			name:         "timeout",
+			err:          ev.NewTimeoutError(ev.MXValidatorName),
+			wantCode:     ev.TimeoutCode,
+			wantCategory: ev.TransientCategory,
+			wantDetails:  map[string]interface{}{"validator": string(ev.MXValidatorName)},
+		},
+		{
+			name:         "skipped",
+			err:          ev.NewSkippedError(ev.SkipReasonDepSkipped),
+			wantCode:     ev.SkippedCode,
+			wantCategory: ev.PolicyCategory,
+			wantDetails:  map[string]interface{}{"reason": ev.SkipReasonDepSkipped},
+		},
+		{
+			name:         "retry",
+			err:          &ev.RetryError{Attempts: make([]ev.RetryAttempt, 2)},
+			wantCode:     ev.RetryCode,
+			wantCategory: ev.TransientCategory,
+			wantDetails:  map[string]interface{}{"attempts": 2},
+		},
+		{
+			name:         "rate limited",
+			err:          &ev.RateLimitedError{Key: "google.com", Reason: ev.RateLimitReasonRate},
+			wantCode:     ev.RateLimitedCode,
+			wantCategory: ev.TransientCategory,
+			wantDetails:  map[string]interface{}{"key": "google.com", "reason": ev.RateLimitReasonRate},
+		},
+		{
+			name:         "subaddress",
+			err:          &ev.SubaddressError{Mailbox: "john@gmail.com", Tag: "promo", Provider: "gmail"},
+			wantCode:     ev.SubaddressCode,
+			wantCategory: ev.PolicyCategory,
+			wantDetails:  map[string]interface{}{"mailbox": "john@gmail.com", "tag": "promo", "provider": "gmail"},
+		},
+		{
+			name:         "suggestion",
+			err:          &ev.SuggestionError{Domain: "gmial.com", Suggestion: "gmail.com", Confidence: 0.9},
+			wantCode:     ev.SuggestionCode,
+			wantCategory: ev.DomainCategory,
+			wantDetails:  map[string]interface{}{"domain": "gmial.com", "suggestion": "gmail.com", "confidence": 0.9},
+		},
+		{
+			name:         "smtp",
+			err:          evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "unknown"}),
+			wantCode:     "smtp_rcpt",
+			wantCategory: ev.MailboxCategory,
+			wantDetails:  map[string]interface{}{"stage": "rcpts", "replyCode": 550, "message": "unknown"},
+		},
+		{
+			name:         "wrapped",
+			err:          fmt.Errorf("wrapped: %w", ev.RoleError{}),
+			wantCode:     ev.RoleCode,
+			wantCategory: ev.PolicyCategory,
+		},
+		{name: "dns not found", err: &net.DNSError{IsNotFound: true}, wantCode: ev.DNSCode, wantCategory: ev.DomainCategory},
+		{name: "dns temporary", err: &net.DNSError{IsTemporary: true}, wantCode: ev.DNSCode, wantCategory: ev.TransientCategory},
+		{name: "canceled", err: context.Canceled, wantCode: ev.CanceledCode, wantCategory: ev.TransientCategory},
+		{name: "deadline", err: context.DeadlineExceeded, wantCode: ev.TimeoutCode, wantCategory: ev.TransientCategory},
+		{name: "unknown", err: errors.New("unknown"), wantCode: ev.UnknownCode, wantCategory: ev.TransientCategory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ev.ToCodedError(tt.err)
+
+			require.Equal(t, tt.wantCode, got.Code())
+			require.Equal(t, tt.wantCategory, got.Category())
+			require.Equal(t, tt.wantDetails, got.Details())
+			require.Contains(t, tt.err.Error(), got.Error())
+		})
+	}
+}
+
+func TestToCodedError_Nil(t *testing.T) {
+	require.Nil(t, ev.ToCodedError(nil))
+}
+
+func TestCodedError_SMTPCategories(t *testing.T) {
+	_, ok := evsmtp.NewError(evsmtp.HelloStage, nil).(ev.CodedError)
+	require.True(t, ok)
+
+	require.Equal(t, ev.MailboxCategory, evsmtp.MailboxCategory)
+	require.Equal(t, ev.PolicyCategory, evsmtp.PolicyCategory)
+	require.Equal(t, ev.TransientCategory, evsmtp.TransientCategory)
+}
@@ -0,0 +1,195 @@
+package evcache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// DefaultMemoSize is default number of keys, which are stored by Memo
+const DefaultMemoSize = 10000
+
+// MemoDTO is DTO for NewMemo
+type MemoDTO struct {
+	// Size is maximal number of stored keys, the least recently used keys are evicted.
+	// DefaultMemoSize is used if it is 0.
+	Size int
+	// Forget returns true for errors, which are not stored (e.g. transient errors), it can be nil.
+	// Errors of context are never stored.
+	Forget func(err error) bool
+}
+
+// NewMemo instantiates Memo
+func NewMemo(dto MemoDTO) *Memo {
+	if dto.Size <= 0 {
+		dto.Size = DefaultMemoSize
+	}
+
+	return &Memo{
+		calls:  make(map[interface{}]*list.Element),
+		order:  list.New(),
+		size:   dto.Size,
+		forget: dto.Forget,
+	}
+}
+
+// Memo stores results of functions by keys, function is called once for each key.
+// Concurrent callers with the same key wait for the first call.
+type Memo struct {
+	mu    sync.Mutex
+	calls map[interface{}]*list.Element
+	// order contains calls from the most recently used
+	order  *list.List
+	size   int
+	forget func(err error) bool
+}
+
+type memoCall struct {
+	key   interface{}
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// PanicError is stored by Memo.Do instead of value, if function panicked
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("PanicError: memo function panicked: %v", p.Value)
+}
+
+// Do returns stored value for key or calls fn to get it.
+// fn is called in a separate goroutine with values of ctx, but without its deadline and cancellation,
+// so the value does not depend on the first caller. Each caller waits for the value until its ctx is done.
+// Errors of context and errors, for which MemoDTO.Forget returns true, are returned, but they are not stored,
+// so the next callers call fn again.
+func (m *Memo) Do(ctx context.Context, key interface{}, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return m.do(ctx, key, fn, false)
+}
+
+// DoInline is Do, but fn is called in the goroutine of the first caller with its ctx.
+// It is used for functions with resources of the caller (e.g. SMTP session), which should not be used
+// after the caller returns. If ctx of the first caller is done, other callers call fn again.
+func (m *Memo) DoInline(ctx context.Context, key interface{}, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return m.do(ctx, key, fn, true)
+}
+
+func (m *Memo) do(ctx context.Context, key interface{}, fn func(ctx context.Context) (interface{}, error), inline bool) (interface{}, error) {
+	for {
+		call, isFirst := m.call(key)
+		if isFirst {
+			if inline {
+				m.run(ctx, call, fn)
+			} else {
+				go m.run(detachedContext{ctx}, call, fn)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-call.done:
+		}
+
+		// fn was interrupted by the first caller, e.g. its connection was closed, so fn is called again
+		if !isFirst && isContextErr(call.err) {
+			continue
+		}
+
+		return call.value, call.err
+	}
+}
+
+// call returns call for key, it adds a new call if the call is absent
+func (m *Memo) call(key interface{}) (*memoCall, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.calls[key]; ok {
+		m.order.MoveToFront(elem)
+		return elem.Value.(*memoCall), false
+	}
+
+	call := &memoCall{key: key, done: make(chan struct{})}
+	m.calls[key] = m.order.PushFront(call)
+	for m.order.Len() > m.size {
+		m.remove(m.order.Back())
+	}
+
+	return call, true
+}
+
+// remove deletes elem of call, callers, which wait for the call, get its value
+func (m *Memo) remove(elem *list.Element) {
+	m.order.Remove(elem)
+	delete(m.calls, elem.Value.(*memoCall).key)
+}
+
+func (m *Memo) run(ctx context.Context, call *memoCall, fn func(ctx context.Context) (interface{}, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.value, call.err = nil, &PanicError{Value: r, Stack: debug.Stack()}
+		}
+
+		if isContextErr(call.err) || (call.err != nil && m.forget != nil && m.forget(call.err)) {
+			m.mu.Lock()
+			if elem, ok := m.calls[call.key]; ok && elem.Value == call {
+				m.remove(elem)
+			}
+			m.mu.Unlock()
+		}
+		close(call.done)
+	}()
+
+	call.value, call.err = fn(ctx)
+}
+
+// Len returns number of stored keys
+func (m *Memo) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// detachedContext keeps values of parent context without its deadline and cancellation
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+type memoCtxKey struct{}
+
+// ContextWithMemo returns context with Memo, validators use it to share results for the same keys (e.g. domains)
+func ContextWithMemo(ctx context.Context, memo *Memo) context.Context {
+	return context.WithValue(ctx, memoCtxKey{}, memo)
+}
+
+// MemoFromContext returns Memo from ctx or nil
+func MemoFromContext(ctx context.Context) *Memo {
+	memo, _ := ctx.Value(memoCtxKey{}).(*Memo)
+
+	return memo
+}
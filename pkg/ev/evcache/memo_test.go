@@ -0,0 +1,179 @@
+package evcache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evcache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemo_Do(t *testing.T) {
+	var calls int32
+	memo := evcache.NewMemo(evcache.MemoDTO{})
+	fn := func(context.Context) (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		return atomic.AddInt32(&calls, 1), nil
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := memo.Do(context.Background(), key, fn)
+			require.NoError(t, err)
+			require.Equal(t, int32(1), got)
+		}()
+	}
+	wg.Wait()
+
+	got, err := memo.Do(context.Background(), "anotherKey", fn)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), got)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestMemo_Do_Panic(t *testing.T) {
+	var calls int32
+	memo := evcache.NewMemo(evcache.MemoDTO{})
+	fn := func(context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		panic("boom")
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := memo.Do(context.Background(), key, fn)
+			require.Nil(t, got)
+
+			var panicErr *evcache.PanicError
+			require.ErrorAs(t, err, &panicErr)
+			require.Equal(t, "boom", panicErr.Value)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	require.Equal(t, "PanicError: memo function panicked: boom", (&evcache.PanicError{Value: "boom"}).Error())
+}
+
+func TestMemo_Do_Context(t *testing.T) {
+	memo := evcache.NewMemo(evcache.MemoDTO{})
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (interface{}, error) {
+		_, hasDeadline := ctx.Deadline()
+		<-release
+		return hasDeadline, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	got, err := memo.Do(ctx, key, fn)
+	require.Nil(t, got)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the call of the first caller is not interrupted by its deadline, its value is shared
+	close(release)
+	got, err = memo.Do(context.Background(), key, fn)
+	require.NoError(t, err)
+	require.Equal(t, false, got)
+}
+
+func TestMemo_Do_Forget(t *testing.T) {
+	errTransient := errors.New("transient")
+	errPermanent := errors.New("permanent")
+
+	var calls int32
+	memo := evcache.NewMemo(evcache.MemoDTO{Forget: func(err error) bool {
+		return errors.Is(err, errTransient)
+	}})
+	fnErr := func(err error) func(context.Context) (interface{}, error) {
+		return func(context.Context) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, err
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := memo.Do(context.Background(), "transient", fnErr(errTransient))
+		require.ErrorIs(t, err, errTransient)
+		_, err = memo.Do(context.Background(), "context", fnErr(context.DeadlineExceeded))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		_, err = memo.Do(context.Background(), "permanent", fnErr(errPermanent))
+		require.ErrorIs(t, err, errPermanent)
+	}
+
+	require.Equal(t, int32(5), atomic.LoadInt32(&calls), "transient and context errors are not stored")
+}
+
+func TestMemo_DoInline(t *testing.T) {
+	memo := evcache.NewMemo(evcache.MemoDTO{})
+	started := make(chan struct{})
+	var finished int32
+	fn := func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		atomic.StoreInt32(&finished, 1)
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	waiter := make(chan interface{})
+	go func() {
+		<-started
+		got, err := memo.DoInline(context.Background(), key, func(context.Context) (interface{}, error) {
+			return "second", nil
+		})
+		require.NoError(t, err)
+		waiter <- got
+	}()
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+	got, err := memo.DoInline(ctx, key, fn)
+	require.Nil(t, got)
+	require.ErrorIs(t, err, context.Canceled)
+	// fn is called in the goroutine of the first caller, so it is finished before the caller returns
+	require.Equal(t, int32(1), atomic.LoadInt32(&finished))
+
+	// the waiter calls fn again after cancellation of the first caller
+	require.Equal(t, "second", <-waiter)
+}
+
+func TestMemo_Do_Size(t *testing.T) {
+	calls := make(map[string]int)
+	memo := evcache.NewMemo(evcache.MemoDTO{Size: 2})
+	do := func(key string) {
+		_, err := memo.Do(context.Background(), key, func(context.Context) (interface{}, error) {
+			calls[key]++
+			return key, nil
+		})
+		require.NoError(t, err)
+	}
+
+	do("a")
+	do("b")
+	do("a")
+	// b is the least recently used key
+	do("c")
+	require.Equal(t, 2, memo.Len())
+	do("a")
+	do("b")
+
+	require.Equal(t, map[string]int{"a": 1, "b": 2, "c": 1}, calls)
+}
+
+func TestMemoFromContext(t *testing.T) {
+	memo := evcache.NewMemo(evcache.MemoDTO{})
+
+	require.Nil(t, evcache.MemoFromContext(context.Background()))
+	require.Same(t, memo, evcache.MemoFromContext(evcache.ContextWithMemo(context.Background(), memo)))
+}
@@ -0,0 +1,58 @@
+package evexplain
+
+import (
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+)
+
+// German is German catalog
+var German = Catalog{
+	OutcomePrefix + ev.OutcomeValid.String():   "Die E-Mail-Adresse ist gültig.",
+	OutcomePrefix + ev.OutcomeInvalid.String(): "Die E-Mail-Adresse ist ungültig.",
+	OutcomePrefix + ev.OutcomeUnknown.String(): "Die E-Mail-Adresse konnte nicht überprüft werden, versuchen Sie es später erneut.",
+
+	ev.SyntaxCategory:    "Die E-Mail-Adresse ist fehlerhaft.",
+	ev.DomainCategory:    "Die Domain kann keine E-Mails empfangen.",
+	ev.MailboxCategory:   "Das Postfach existiert nicht.",
+	ev.PolicyCategory:    "Die E-Mail-Adresse ist nicht erlaubt.",
+	ev.TransientCategory: "Die Prüfung ist vorübergehend fehlgeschlagen: {error}.",
+
+	ev.SyntaxCode:                           "Die E-Mail-Adresse ist fehlerhaft.",
+	ev.EmptyMXsCode:                         "Die Domain hat keine Mailserver.",
+	ev.DNSCode:                              "Die Domain existiert nicht.",
+	ev.DNSCode + "." + ev.TransientCategory: "Die DNS-Abfrage der Domain ist vorübergehend fehlgeschlagen.",
+	ev.DisposableCode:                       "Die Domain gehört zu einem Wegwerf-E-Mail-Anbieter.",
+	ev.FreeCode:                             "Die Domain gehört zu einem kostenlosen E-Mail-Anbieter.",
+	ev.RoleCode:                             "Das Postfach ist ein Funktionskonto, z. B. admin oder support.",
+	ev.BanWordsUsernameCode:                 "Der Benutzername enthält verbotene Wörter.",
+	ev.BlackListDomainsCode:                 "Die Domain steht auf der Sperrliste.",
+	ev.BlackListEmailsCode:                  "Die E-Mail-Adresse steht auf der Sperrliste.",
+	ev.WhiteListCode:                        "Die Domain steht nicht auf der Positivliste.",
+	ev.GravatarCode:                         "Die E-Mail-Adresse hat keinen Gravatar.",
+	ev.DepsCode:                             "Die Prüfung wurde nicht ausgeführt, weil eine erforderliche Prüfung fehlgeschlagen ist.",
+	ev.SkippedCode:                          "Die Prüfung wurde übersprungen: {reason}.",
+	ev.NotApplicableCode:                    "Die Prüfung ist nicht anwendbar.",
+	ev.TimeoutCode:                          "Die Zeit für die Prüfung ist abgelaufen.",
+	ev.CanceledCode:                         "Die Prüfung wurde abgebrochen.",
+	ev.PanicCode:                            "Die Prüfung {validator} ist unerwartet fehlgeschlagen.",
+	ev.RetryCode:                            "Die Prüfung wurde {attempts} Mal wiederholt.",
+	ev.RateLimitedCode:                      "Die Prüfung wurde wegen des Ratenlimits von {key} verschoben.",
+	ev.SubaddressCode:                       "Die E-Mail-Adresse ist eine Unteradresse von {mailbox} mit dem Tag \"{tag}\".",
+	ev.SuggestionCode:                       "Die Domain {domain} ist möglicherweise falsch geschrieben, meinten Sie {suggestion}?",
+	ev.UnknownCode:                          "Die Prüfung ist fehlgeschlagen: {error}.",
+
+	"smtp_client":                       "Der Mailserver hat die SMTP-Sitzung nicht gestartet{reply}.",
+	"smtp_hello":                        "Der Mailserver hat die Begrüßung abgelehnt{reply}.",
+	"smtp_auth":                         "Die Anmeldung am Mailserver ist fehlgeschlagen{reply}.",
+	"smtp_mail":                         "Der Mailserver hat den Absender abgelehnt{reply}.",
+	"smtp_mail." + ev.TransientCategory: "Der Mailserver hat den Absender vorübergehend abgelehnt{reply}.",
+	"smtp_rcpt":                         "Der Mailserver hat dieses Postfach abgelehnt{reply}.",
+	"smtp_rcpt." + ev.TransientCategory: "Der Mailserver hat dieses Postfach vorübergehend zurückgestellt, z. B. durch Greylisting{reply}.",
+	"smtp_random_rcpt":                  "Der Mailserver hat ein zufälliges Postfach abgelehnt, er ist also kein Catch-All-Server{reply}.",
+	"smtp_random_rcpt." + ev.TransientCategory: "Der Mailserver hat ein zufälliges Postfach vorübergehend zurückgestellt{reply}.",
+	"smtp_quit":             "Der Mailserver hat die SMTP-Sitzung nicht ordnungsgemäß beendet{reply}.",
+	"smtp_close":            "Die Verbindung zum Mailserver wurde nicht ordnungsgemäß geschlossen.",
+	"smtp_connection":       "Es konnte keine Verbindung zu einem Mailserver der Domain hergestellt werden.",
+	"smtp_circuit_open":     "Die Mailserver {hosts} sind nicht erreichbar, die Prüfung wurde verschoben.",
+	"smtp_utf8_unsupported": "Der Mailserver unterstützt keine internationalisierten Postfächer wie {mailbox}.",
+	"smtp_unknown":          "Der Mailserver ist fehlgeschlagen{reply}.",
+}
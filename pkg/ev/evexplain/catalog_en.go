@@ -0,0 +1,58 @@
+package evexplain
+
+import (
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+)
+
+// English is English catalog
+var English = Catalog{
+	OutcomePrefix + ev.OutcomeValid.String():   "The email address is valid.",
+	OutcomePrefix + ev.OutcomeInvalid.String(): "The email address is invalid.",
+	OutcomePrefix + ev.OutcomeUnknown.String(): "The email address could not be verified, try again later.",
+
+	ev.SyntaxCategory:    "The email address is malformed.",
+	ev.DomainCategory:    "The domain can not receive emails.",
+	ev.MailboxCategory:   "The mailbox does not exist.",
+	ev.PolicyCategory:    "The email address is not allowed.",
+	ev.TransientCategory: "The check failed temporarily: {error}.",
+
+	ev.SyntaxCode:                           "The email address is malformed.",
+	ev.EmptyMXsCode:                         "The domain has no mail servers.",
+	ev.DNSCode:                              "The domain does not exist.",
+	ev.DNSCode + "." + ev.TransientCategory: "The DNS lookup of the domain failed temporarily.",
+	ev.DisposableCode:                       "The domain is a disposable email provider.",
+	ev.FreeCode:                             "The domain is a free email provider.",
+	ev.RoleCode:                             "The mailbox is a role account, e.g. admin or support.",
+	ev.BanWordsUsernameCode:                 "The username contains banned words.",
+	ev.BlackListDomainsCode:                 "The domain is blacklisted.",
+	ev.BlackListEmailsCode:                  "The email address is blacklisted.",
+	ev.WhiteListCode:                        "The domain is not whitelisted.",
+	ev.GravatarCode:                         "The email address has no Gravatar.",
+	ev.DepsCode:                             "The check was not run, because a required check failed.",
+	ev.SkippedCode:                          "The check was skipped: {reason}.",
+	ev.NotApplicableCode:                    "The check is not applicable.",
+	ev.TimeoutCode:                          "The check timed out.",
+	ev.CanceledCode:                         "The check was canceled.",
+	ev.PanicCode:                            "The check {validator} failed unexpectedly.",
+	ev.RetryCode:                            "The check was retried {attempts} times.",
+	ev.RateLimitedCode:                      "The check was postponed by the rate limit of {key}.",
+	ev.SubaddressCode:                       "The email address is a subaddress of {mailbox} with the tag \"{tag}\".",
+	ev.SuggestionCode:                       "The domain {domain} may be misspelled, did you mean {suggestion}?",
+	ev.UnknownCode:                          "The check failed: {error}.",
+
+	"smtp_client":                       "The mail server did not start the SMTP session{reply}.",
+	"smtp_hello":                        "The mail server rejected the greeting{reply}.",
+	"smtp_auth":                         "The authentication on the mail server failed{reply}.",
+	"smtp_mail":                         "The mail server rejected the sender{reply}.",
+	"smtp_mail." + ev.TransientCategory: "The mail server temporarily rejected the sender{reply}.",
+	"smtp_rcpt":                         "The mail server rejected this mailbox{reply}.",
+	"smtp_rcpt." + ev.TransientCategory: "The mail server temporarily deferred this mailbox, e.g. by greylisting{reply}.",
+	"smtp_random_rcpt":                  "The mail server rejected a random mailbox, so it is not a catch-all server{reply}.",
+	"smtp_random_rcpt." + ev.TransientCategory: "The mail server temporarily deferred a random mailbox{reply}.",
+	"smtp_quit":             "The mail server failed to close the SMTP session{reply}.",
+	"smtp_close":            "The connection to the mail server was not closed properly.",
+	"smtp_connection":       "Could not connect to any mail server of the domain.",
+	"smtp_circuit_open":     "The mail servers {hosts} are unavailable, the check was postponed.",
+	"smtp_utf8_unsupported": "The mail server does not support internationalized mailboxes like {mailbox}.",
+	"smtp_unknown":          "The mail server failed{reply}.",
+}
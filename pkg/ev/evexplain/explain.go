@@ -0,0 +1,219 @@
+package evexplain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+)
+
+// DefaultLocale is locale of fallback catalog
+const DefaultLocale = "en"
+
+// OutcomePrefix is prefix of keys of outcomes in Catalog, e.g. "outcome.invalid"
+const OutcomePrefix = "outcome."
+
+// Catalog is message catalog of locale. Keys are codes of ev.CodedError, codes with category
+// (e.g. "smtp_rcpt.transient"), categories and outcomes with OutcomePrefix.
+// Messages contain placeholders of details of errors, e.g. {validator}, {reply} is ": <reply code> <message>" of server
+// and {error} is text of error.
+type Catalog map[string]string
+
+// DefaultCatalogs returns English and German catalogs
+func DefaultCatalogs() map[string]Catalog {
+	return map[string]Catalog{
+		"en": English,
+		"de": German,
+	}
+}
+
+// ExplainerDTO is DTO for NewExplainer
+type ExplainerDTO struct {
+	// Catalogs by locales, DefaultCatalogs are used if nil
+	Catalogs map[string]Catalog
+	// DefaultLocale is locale for unknown locales and missing messages, DefaultLocale is used if empty
+	DefaultLocale string
+}
+
+// NewExplainer instantiates Explainer
+func NewExplainer(dto ExplainerDTO) *Explainer {
+	if dto.Catalogs == nil {
+		dto.Catalogs = DefaultCatalogs()
+	}
+	if dto.DefaultLocale == "" {
+		dto.DefaultLocale = DefaultLocale
+	}
+
+	catalogs := make(map[string]Catalog, len(dto.Catalogs))
+	for locale, catalog := range dto.Catalogs {
+		catalogs[normalizeLocale(locale)] = catalog
+	}
+
+	return &Explainer{
+		catalogs:      catalogs,
+		defaultLocale: normalizeLocale(dto.DefaultLocale),
+	}
+}
+
+// Explainer turns results of validation into short human sentences
+type Explainer struct {
+	catalogs      map[string]Catalog
+	defaultLocale string
+}
+
+// Explanation is explanation of result
+type Explanation struct {
+	// Locale is locale of used catalog
+	Locale string `json:"locale"`
+	// Summary explains outcome
+	Summary  string   `json:"summary"`
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// String joins summary and errors
+func (e Explanation) String() string {
+	return strings.Join(append([]string{e.Summary}, e.Errors...), " ")
+}
+
+// normalizeLocale converts locale to lower case with "-" separator, e.g. pt_BR to pt-br
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// Locale returns the nearest locale with catalog, e.g. "de" for "de-AT", or default locale
+func (e *Explainer) Locale(locale string) string {
+	locale = normalizeLocale(locale)
+	for locale != "" {
+		if _, ok := e.catalogs[locale]; ok {
+			return locale
+		}
+
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+
+	return e.defaultLocale
+}
+
+// message returns message by the first of keys, which is found in catalog of locale or default locale
+func (e *Explainer) message(locale string, keys ...string) string {
+	for _, catalogLocale := range []string{locale, e.defaultLocale} {
+		catalog := e.catalogs[catalogLocale]
+		for _, key := range keys {
+			if message, ok := catalog[key]; ok {
+				return message
+			}
+		}
+	}
+
+	return ""
+}
+
+// Outcome explains outcome
+func (e *Explainer) Outcome(outcome ev.Outcome, locale string) string {
+	return e.message(e.Locale(locale), OutcomePrefix+outcome.String())
+}
+
+// Error explains err by its code, category and details
+func (e *Explainer) Error(err error, locale string) string {
+	coded := ev.ToCodedError(err)
+	if coded == nil {
+		return ""
+	}
+
+	message := e.message(e.Locale(locale),
+		coded.Code()+"."+coded.Category(),
+		coded.Code(),
+		coded.Category(),
+		ev.UnknownCode,
+	)
+	if message == "" {
+		return err.Error()
+	}
+
+	return placeholders(err, coded.Details()).Replace(message)
+}
+
+// placeholders returns replacer of placeholders of details, {reply} and {error}
+func placeholders(err error, details map[string]interface{}) *strings.Replacer {
+	var reply string
+	if code, ok := details["replyCode"]; ok {
+		reply = fmt.Sprintf(": %v %v", code, details["message"])
+	}
+
+	oldNew := []string{"{reply}", reply, "{error}", err.Error()}
+	for key, value := range details {
+		if values, ok := value.([]string); ok {
+			value = strings.Join(values, ", ")
+		}
+		oldNew = append(oldNew, "{"+key+"}", fmt.Sprint(value))
+	}
+
+	return strings.NewReplacer(oldNew...)
+}
+
+// Explain explains result in locale, errors of DepValidationResult are explained by names of validators.
+// Equal sentences are explained once.
+func (e *Explainer) Explain(result ev.ValidationResult, locale string) Explanation {
+	locale = e.Locale(locale)
+	explanation := Explanation{Locale: locale}
+	if result == nil {
+		return explanation
+	}
+
+	explanation.Summary = e.Outcome(result.Outcome(), locale)
+	for _, nested := range nestedResults(result) {
+		explanation.Errors = e.appendErrors(explanation.Errors, nested.Errors(), locale)
+		explanation.Warnings = e.appendErrors(explanation.Warnings, nested.Warnings(), locale)
+	}
+
+	return explanation
+}
+
+func (e *Explainer) appendErrors(sentences []string, errs []error, locale string) []string {
+	for _, err := range errs {
+		sentence := e.Error(err, locale)
+		if sentence != "" && !contains(sentences, sentence) {
+			sentences = append(sentences, sentence)
+		}
+	}
+
+	return sentences
+}
+
+// nestedResults returns nested results of DepValidationResult sorted by names or result itself
+func nestedResults(result ev.ValidationResult) []ev.ValidationResult {
+	depResult, ok := result.(ev.DepValidationResult)
+	if !ok {
+		return []ev.ValidationResult{result}
+	}
+
+	results := depResult.GetResults()
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	nested := make([]ev.ValidationResult, 0, len(names))
+	for _, name := range names {
+		nested = append(nested, nestedResults(results[ev.ValidatorName(name)])...)
+	}
+
+	return nested
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+package evexplain_test
+
+import (
+	"errors"
+	"net/textproto"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evexplain"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/stretchr/testify/require"
+)
+
+var rejectedResult = ev.NewDepValidatorResult(false, ev.DepResult{
+	ev.SyntaxValidatorName: ev.NewValidResult(ev.SyntaxValidatorName),
+	ev.RoleValidatorName:   ev.NewResult(false, utils.Errs(ev.RoleError{}), nil, ev.RoleValidatorName),
+	ev.SMTPValidatorName: ev.NewResult(false,
+		utils.Errs(evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "user unknown"})),
+		utils.Errs(evsmtp.NewError(evsmtp.RandomRCPTStage, &textproto.Error{Code: 550, Msg: "user unknown"})),
+		ev.SMTPValidatorName,
+	),
+})
+
+func TestExplainer_Explain(t *testing.T) {
+	tests := []struct {
+		name   string
+		result ev.ValidationResult
+		locale string
+		want   evexplain.Explanation
+	}{
+		{
+			name:   "english",
+			result: rejectedResult,
+			locale: "en-US",
+			want: evexplain.Explanation{
+				Locale:  "en",
+				Summary: "The email address is invalid.",
+				Errors: []string{
+					"The mailbox is a role account, e.g. admin or support.",
+					"The mail server rejected this mailbox: 550 user unknown.",
+				},
+				Warnings: []string{"The mail server rejected a random mailbox, so it is not a catch-all server: 550 user unknown."},
+			},
+		},
+		{
+			name:   "german",
+			result: rejectedResult,
+			locale: "de_AT",
+			want: evexplain.Explanation{
+				Locale:  "de",
+				Summary: "Die E-Mail-Adresse ist ungültig.",
+				Errors: []string{
+					"Das Postfach ist ein Funktionskonto, z. B. admin oder support.",
+					"Der Mailserver hat dieses Postfach abgelehnt: 550 user unknown.",
+				},
+				Warnings: []string{"Der Mailserver hat ein zufälliges Postfach abgelehnt, er ist also kein Catch-All-Server: 550 user unknown."},
+			},
+		},
+		{
+			name: "unknown locale and duplicates",
+			result: ev.NewResultWithOutcome(ev.OutcomeUnknown, utils.Errs(
+				evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 451, Msg: "greylisted"}),
+				evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 451, Msg: "greylisted"}),
+				evsmtp.ErrConnection,
+			), nil, ev.SMTPValidatorName),
+			locale: "fr",
+			want: evexplain.Explanation{
+				Locale:  "en",
+				Summary: "The email address could not be verified, try again later.",
+				Errors: []string{
+					"The mail server temporarily deferred this mailbox, e.g. by greylisting: 451 greylisted.",
+					"Could not connect to any mail server of the domain.",
+				},
+			},
+		},
+		{
+			name:   "valid",
+			result: ev.NewValidResult(ev.SyntaxValidatorName),
+			want:   evexplain.Explanation{Locale: "en", Summary: "The email address is valid."},
+		},
+		{
+			name:   "nil",
+			result: nil,
+			want:   evexplain.Explanation{Locale: "en"},
+		},
+	}
+	explainer := evexplain.NewExplainer(evexplain.ExplainerDTO{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, explainer.Explain(tt.result, tt.locale))
+		})
+	}
+}
+
+func TestExplainer_Error(t *testing.T) {
+	explainer := evexplain.NewExplainer(evexplain.ExplainerDTO{})
+
+	require.Equal(t, "The check SMTPValidator failed unexpectedly.", explainer.Error(ev.NewPanicError(ev.SMTPValidatorName, "boom", nil), ""))
+	require.Equal(t, "The mail servers mx1, mx2 are unavailable, the check was postponed.",
+		explainer.Error(evsmtp.NewError(evsmtp.CircuitOpenStage, &evsmtp.CircuitOpenError{Hosts: []string{"mx1", "mx2"}}), "en"))
+	require.Equal(t, "The mail server does not support internationalized mailboxes like josé@example.com.",
+		explainer.Error(evsmtp.NewError(evsmtp.MailStage, &evsmtp.SMTPUTF8Error{Mailbox: "josé@example.com"}), "en"))
+	require.Equal(t, `The email address is a subaddress of john@gmail.com with the tag "promo".`,
+		explainer.Error(&ev.SubaddressError{Mailbox: "john@gmail.com", Tag: "promo"}, "en"))
+	require.Equal(t, "The domain gmial.com may be misspelled, did you mean gmail.com?",
+		explainer.Error(&ev.SuggestionError{Domain: "gmial.com", Suggestion: "gmail.com", Confidence: 0.9}, "en"))
+	require.Equal(t, "The mail server rejected the sender.", explainer.Error(evsmtp.NewError(evsmtp.MailStage, errors.New("connection closed")), "en"))
+	require.Equal(t, "", explainer.Error(nil, "en"))
+}
+
+func TestExplainer_CustomCatalog(t *testing.T) {
+	catalogs := evexplain.DefaultCatalogs()
+	catalogs["en-GB"] = evexplain.Catalog{ev.RoleCode: "The mailbox is a role account, e.g. postmaster."}
+	explainer := evexplain.NewExplainer(evexplain.ExplainerDTO{Catalogs: catalogs})
+
+	explanation := explainer.Explain(rejectedResult, "en-GB")
+	require.Equal(t, "en-gb", explanation.Locale)
+	require.Equal(t, "The email address is invalid. The mailbox is a role account, e.g. postmaster. "+
+		"The mail server rejected this mailbox: 550 user unknown.", explanation.String())
+
+	// messages without translations are explained as error text
+	explainer = evexplain.NewExplainer(evexplain.ExplainerDTO{Catalogs: map[string]evexplain.Catalog{}})
+	require.Equal(t, ev.RoleErr, explainer.Error(ev.RoleError{}, "en"))
+}
+
+func TestCatalogs(t *testing.T) {
+	codes := []string{
+		ev.SyntaxCode, ev.EmptyMXsCode, ev.DNSCode, ev.DisposableCode, ev.FreeCode, ev.RoleCode,
+		ev.BanWordsUsernameCode, ev.BlackListDomainsCode, ev.BlackListEmailsCode, ev.WhiteListCode,
+		ev.GravatarCode, ev.DepsCode, ev.SkippedCode, ev.NotApplicableCode, ev.TimeoutCode, ev.CanceledCode,
+		ev.PanicCode, ev.RetryCode, ev.RateLimitedCode, ev.SubaddressCode, ev.SuggestionCode, ev.UnknownCode, evsmtp.UnknownStageCode, evsmtp.SMTPUTF8Code,
+		ev.SyntaxCategory, ev.DomainCategory, ev.MailboxCategory, ev.PolicyCategory, ev.TransientCategory,
+	}
+	for stage := evsmtp.ClientStage; stage <= evsmtp.CircuitOpenStage; stage++ {
+		codes = append(codes, evsmtp.StageCode(stage))
+	}
+	for _, outcome := range []ev.Outcome{ev.OutcomeValid, ev.OutcomeInvalid, ev.OutcomeUnknown} {
+		codes = append(codes, evexplain.OutcomePrefix+outcome.String())
+	}
+
+	for locale, catalog := range evexplain.DefaultCatalogs() {
+		t.Run(locale, func(t *testing.T) {
+			for _, code := range codes {
+				require.NotEmpty(t, catalog[code], code)
+			}
+			for key := range evexplain.English {
+				require.NotEmpty(t, catalog[key], key)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package evmail
+
+import (
+	"strings"
+)
+
+// TransformationType is type of Transformation of Canonicalize
+type TransformationType string
+
+// Types of Transformation
+const (
+	// DomainAliasTransformation replaces alias of domain by canonical domain, e.g. googlemail.com by gmail.com
+	DomainAliasTransformation TransformationType = "domain_alias"
+	// RemoveTagTransformation removes tag of subaddress, e.g. john+promo by john
+	RemoveTagTransformation TransformationType = "remove_tag"
+	// RemoveDotsTransformation removes dots from local part, e.g. j.o.h.n by john
+	RemoveDotsTransformation TransformationType = "remove_dots"
+)
+
+// Transformation is change of address, which was applied by Canonicalize
+type Transformation struct {
+	Type TransformationType
+	// From is part of address before transformation, e.g. john+promo
+	From string
+	// To is part of address after transformation, e.g. john
+	To string
+}
+
+// ProviderRule is rule of canonicalization of addresses of provider
+type ProviderRule struct {
+	// Provider is name of provider, e.g. gmail
+	Provider string
+	// Domain is canonical domain, aliases of provider are replaced by it, e.g. gmail.com for googlemail.com.
+	// Domain of address is kept if it is empty.
+	Domain string
+	// RemoveDots removes dots from local part, e.g. j.o.h.n@gmail.com is john@gmail.com
+	RemoveDots bool
+	// TagSeparators separate mailbox and tag of subaddress, e.g. "+" for john+promo@gmail.com
+	TagSeparators []string
+}
+
+// SplitTag splits local part by the first tag separator of rule into mailbox and tag,
+// ok is false if local part has no tag or mailbox is empty
+func (r ProviderRule) SplitTag(localPart string) (mailbox, tag string, ok bool) {
+	pos, sepLen := -1, 0
+	for _, sep := range r.TagSeparators {
+		if sep == "" {
+			continue
+		}
+		if i := strings.Index(localPart, sep); i != -1 && (pos == -1 || i < pos) {
+			pos, sepLen = i, len(sep)
+		}
+	}
+
+	if pos < 1 {
+		return localPart, "", false
+	}
+
+	return localPart[:pos], localPart[pos+sepLen:], true
+}
+
+// ProviderRules are rules of providers by ASCII domains
+type ProviderRules map[string]ProviderRule
+
+// Rule returns rule of domain
+func (p ProviderRules) Rule(domain string) (ProviderRule, bool) {
+	rule, ok := p[strings.ToLower(domain)]
+
+	return rule, ok
+}
+
+// Providers of DefaultProviderRules
+const (
+	GmailProvider   = "gmail"
+	YahooProvider   = "yahoo"
+	OutlookProvider = "outlook"
+	ICloudProvider  = "icloud"
+)
+
+// DefaultProviderRules returns rules of Gmail, Yahoo, Outlook/Hotmail and iCloud.
+// Each call returns new map, so it can be extended by rules for other domains.
+func DefaultProviderRules() ProviderRules {
+	gmail := ProviderRule{
+		Provider:      GmailProvider,
+		Domain:        "gmail.com",
+		RemoveDots:    true,
+		TagSeparators: []string{"+"},
+	}
+	yahoo := ProviderRule{
+		Provider:      YahooProvider,
+		TagSeparators: []string{"-"},
+	}
+	outlook := ProviderRule{
+		Provider:      OutlookProvider,
+		TagSeparators: []string{"+"},
+	}
+	icloud := ProviderRule{
+		Provider:      ICloudProvider,
+		TagSeparators: []string{"+"},
+	}
+
+	rules := ProviderRules{
+		"gmail.com":      gmail,
+		"googlemail.com": gmail,
+	}
+	for _, domain := range []string{
+		"yahoo.com", "ymail.com", "rocketmail.com", "yahoo.co.uk", "yahoo.fr", "yahoo.de",
+		"yahoo.es", "yahoo.it", "yahoo.ca", "yahoo.co.in", "yahoo.com.au", "yahoo.com.br", "yahoo.co.jp",
+	} {
+		rules[domain] = yahoo
+	}
+	for _, domain := range []string{
+		"outlook.com", "hotmail.com", "live.com", "msn.com", "hotmail.co.uk", "hotmail.fr",
+		"hotmail.de", "hotmail.it", "hotmail.es", "live.co.uk", "live.fr", "outlook.fr", "outlook.de",
+	} {
+		rules[domain] = outlook
+	}
+	for _, domain := range []string{"icloud.com", "me.com", "mac.com"} {
+		rules[domain] = icloud
+	}
+
+	return rules
+}
+
+// Canonical is result of Canonicalize
+type Canonical struct {
+	// Address is canonical address, it is the original address if Transformations are empty
+	Address Address
+	// Transformations are applied changes in order of application
+	Transformations []Transformation
+}
+
+// CanonicalGroup is group of addresses with the same canonical address
+type CanonicalGroup struct {
+	Canonical Address
+	// Addresses are in order of input
+	Addresses []Address
+}
+
+// CanonicalizerDTO is DTO for NewCanonicalizer
+type CanonicalizerDTO struct {
+	// Rules are DefaultProviderRules() if nil
+	Rules ProviderRules
+}
+
+// NewCanonicalizer instantiates Canonicalizer
+func NewCanonicalizer(dto CanonicalizerDTO) *Canonicalizer {
+	if dto.Rules == nil {
+		dto.Rules = DefaultProviderRules()
+	}
+
+	return &Canonicalizer{rules: dto.Rules}
+}
+
+// Canonicalizer converts addresses to canonical forms by rules of providers.
+// Addresses of domains without rules are not changed.
+type Canonicalizer struct {
+	rules ProviderRules
+}
+
+// Rules returns rules of providers
+func (c *Canonicalizer) Rules() ProviderRules {
+	return c.rules
+}
+
+// Canonicalize returns canonical address and applied transformations,
+// tags are removed before dots, domain aliases are replaced the last
+func (c *Canonicalizer) Canonicalize(addr Address) Canonical {
+	if addr == nil {
+		return Canonical{}
+	}
+
+	domain := ASCIIDomain(addr)
+	rule, ok := c.rules.Rule(domain)
+	if !ok {
+		return Canonical{Address: addr}
+	}
+
+	var transformations []Transformation
+	localPart := addr.Username()
+	if mailbox, _, ok := rule.SplitTag(localPart); ok {
+		transformations = append(transformations, Transformation{Type: RemoveTagTransformation, From: localPart, To: mailbox})
+		localPart = mailbox
+	}
+	if withoutDots := strings.ReplaceAll(localPart, ".", ""); rule.RemoveDots && withoutDots != localPart && withoutDots != "" {
+		transformations = append(transformations, Transformation{Type: RemoveDotsTransformation, From: localPart, To: withoutDots})
+		localPart = withoutDots
+	}
+	if rule.Domain != "" && rule.Domain != domain {
+		transformations = append(transformations, Transformation{Type: DomainAliasTransformation, From: domain, To: rule.Domain})
+		domain = rule.Domain
+	}
+
+	if len(transformations) == 0 {
+		return Canonical{Address: addr}
+	}
+
+	return Canonical{
+		Address:         NewEmailAddress(localPart, domain),
+		Transformations: transformations,
+	}
+}
+
+// Dedup groups addresses by canonical addresses, groups are in order of the first addresses
+func (c *Canonicalizer) Dedup(addrs []Address) []CanonicalGroup {
+	groups := make([]CanonicalGroup, 0, len(addrs))
+	indexes := make(map[string]int, len(addrs))
+	for _, addr := range addrs {
+		if addr == nil {
+			continue
+		}
+
+		canonical := c.Canonicalize(addr).Address
+		key := ASCIIAddress(canonical)
+		if i, ok := indexes[key]; ok {
+			groups[i].Addresses = append(groups[i].Addresses, addr)
+			continue
+		}
+
+		indexes[key] = len(groups)
+		groups = append(groups, CanonicalGroup{Canonical: canonical, Addresses: []Address{addr}})
+	}
+
+	return groups
+}
+
+var defaultCanonicalizer = NewCanonicalizer(CanonicalizerDTO{})
+
+// Canonicalize converts address to canonical form by DefaultProviderRules
+func Canonicalize(addr Address) Canonical {
+	return defaultCanonicalizer.Canonicalize(addr)
+}
+
+// Dedup groups addresses by canonical forms by DefaultProviderRules
+func Dedup(addrs []Address) []CanonicalGroup {
+	return defaultCanonicalizer.Dedup(addrs)
+}
@@ -0,0 +1,149 @@
+package evmail_test
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name                string
+		email               evmail.Address
+		want                evmail.Address
+		wantTransformations []evmail.Transformation
+	}{
+		{
+			name:  "gmail dots and tag",
+			email: evmail.FromString("J.O.H.N+Promo@gmail.com"),
+			want:  evmail.NewEmailAddress("john", "gmail.com"),
+			wantTransformations: []evmail.Transformation{
+				{Type: evmail.RemoveTagTransformation, From: "j.o.h.n+promo", To: "j.o.h.n"},
+				{Type: evmail.RemoveDotsTransformation, From: "j.o.h.n", To: "john"},
+			},
+		},
+		{
+			name:  "googlemail alias",
+			email: evmail.FromString("jo.hn@googlemail.com"),
+			want:  evmail.NewEmailAddress("john", "gmail.com"),
+			wantTransformations: []evmail.Transformation{
+				{Type: evmail.RemoveDotsTransformation, From: "jo.hn", To: "john"},
+				{Type: evmail.DomainAliasTransformation, From: "googlemail.com", To: "gmail.com"},
+			},
+		},
+		{
+			name:                "canonical gmail",
+			email:               evmail.FromString("john@gmail.com"),
+			want:                evmail.FromString("john@gmail.com"),
+			wantTransformations: nil,
+		},
+		{
+			name:  "yahoo alias",
+			email: evmail.FromString("john.doe-shop@yahoo.com"),
+			want:  evmail.NewEmailAddress("john.doe", "yahoo.com"),
+			wantTransformations: []evmail.Transformation{
+				{Type: evmail.RemoveTagTransformation, From: "john.doe-shop", To: "john.doe"},
+			},
+		},
+		{
+			name:  "outlook tag keeps dots",
+			email: evmail.FromString("john.doe+news@hotmail.com"),
+			want:  evmail.NewEmailAddress("john.doe", "hotmail.com"),
+			wantTransformations: []evmail.Transformation{
+				{Type: evmail.RemoveTagTransformation, From: "john.doe+news", To: "john.doe"},
+			},
+		},
+		{
+			name:                "tag without mailbox",
+			email:               evmail.FromString("+promo@outlook.com"),
+			want:                evmail.FromString("+promo@outlook.com"),
+			wantTransformations: nil,
+		},
+		{
+			name:                "unknown domain",
+			email:               evmail.FromString("j.o.h.n+promo@example.com"),
+			want:                evmail.FromString("j.o.h.n+promo@example.com"),
+			wantTransformations: nil,
+		},
+		{
+			name:                "nil",
+			email:               nil,
+			want:                nil,
+			wantTransformations: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evmail.Canonicalize(tt.email)
+			require.Equal(t, tt.want, got.Address)
+			require.Equal(t, tt.wantTransformations, got.Transformations)
+		})
+	}
+}
+
+func TestCanonicalizer_CustomRules(t *testing.T) {
+	rules := evmail.DefaultProviderRules()
+	rules["example.com"] = evmail.ProviderRule{Provider: "example", TagSeparators: []string{"=", "+"}}
+	rules["example.org"] = evmail.ProviderRule{Provider: "example", Domain: "example.com"}
+	c := evmail.NewCanonicalizer(evmail.CanonicalizerDTO{Rules: rules})
+
+	got := c.Canonicalize(evmail.FromString("john=a+b@Example.com"))
+	require.Equal(t, evmail.NewEmailAddress("john", "example.com"), got.Address)
+	require.Equal(t, []evmail.Transformation{
+		{Type: evmail.RemoveTagTransformation, From: "john=a+b", To: "john"},
+	}, got.Transformations)
+
+	got = c.Canonicalize(evmail.FromString("john@example.org"))
+	require.Equal(t, evmail.NewEmailAddress("john", "example.com"), got.Address)
+
+	require.Len(t, evmail.DefaultProviderRules(), len(rules)-2)
+}
+
+func TestProviderRule_SplitTag(t *testing.T) {
+	rule := evmail.ProviderRule{TagSeparators: []string{"", "+", "-"}}
+
+	tests := []struct {
+		localPart   string
+		wantMailbox string
+		wantTag     string
+		wantOk      bool
+	}{
+		{localPart: "john+promo", wantMailbox: "john", wantTag: "promo", wantOk: true},
+		{localPart: "john-a+b", wantMailbox: "john", wantTag: "a+b", wantOk: true},
+		{localPart: "john+", wantMailbox: "john", wantTag: "", wantOk: true},
+		{localPart: "+promo", wantMailbox: "+promo", wantTag: "", wantOk: false},
+		{localPart: "john", wantMailbox: "john", wantTag: "", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.localPart, func(t *testing.T) {
+			mailbox, tag, ok := rule.SplitTag(tt.localPart)
+			require.Equal(t, tt.wantMailbox, mailbox)
+			require.Equal(t, tt.wantTag, tag)
+			require.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestDedup(t *testing.T) {
+	addrs := []evmail.Address{
+		evmail.FromString("j.o.h.n+promo@gmail.com"),
+		evmail.FromString("jane@example.com"),
+		nil,
+		evmail.FromString("John@googlemail.com"),
+		evmail.FromString("jane@example.com"),
+		evmail.FromString("john@gmail.com"),
+	}
+
+	require.Equal(t, []evmail.CanonicalGroup{
+		{
+			Canonical: evmail.NewEmailAddress("john", "gmail.com"),
+			Addresses: []evmail.Address{addrs[0], addrs[3], addrs[5]},
+		},
+		{
+			Canonical: addrs[1],
+			Addresses: []evmail.Address{addrs[1], addrs[4]},
+		},
+	}, evmail.Dedup(addrs))
+	require.Empty(t, evmail.Dedup(nil))
+}
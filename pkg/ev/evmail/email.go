@@ -54,11 +54,12 @@ func (e address) String() string {
 	return e.source
 }
 
-// SeparateEmail separates email by "@" and returns two parts
+// SeparateEmail separates email by the last "@" and returns two parts,
+// the domain can not contain "@", but the quoted local part can, e.g. "a@b"@domain.com
 func SeparateEmail(email string) (string, string) {
-	pos := strings.IndexByte(email, '@')
+	pos := strings.LastIndexByte(email, '@')
 
-	if pos == -1 || len(email) < 3 {
+	if pos == -1 {
 		return "", ""
 	}
 
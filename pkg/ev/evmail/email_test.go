@@ -187,6 +187,24 @@ func TestSeparatedEmail(t *testing.T) {
 			want:  emptyUsername,
 			want1: emptyDomain,
 		},
+		{
+			name:  "quoted at",
+			args:  args{`"a@b"@example.com`},
+			want:  `"a@b"`,
+			want1: "example.com",
+		},
+		{
+			name:  "short",
+			args:  args{"a@"},
+			want:  "a",
+			want1: emptyDomain,
+		},
+		{
+			name:  "without at",
+			args:  args{"email"},
+			want:  emptyUsername,
+			want1: emptyDomain,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
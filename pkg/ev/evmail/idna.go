@@ -0,0 +1,119 @@
+package evmail
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// IDNAddress is Address with forms of internationalized domain name
+type IDNAddress interface {
+	Address
+	// ASCIIDomain is domain in ASCII form by IDNA2008, e.g. xn--bcher-kva.de
+	ASCIIDomain() string
+	// UnicodeDomain is domain in Unicode form by IDNA2008, e.g. bücher.de
+	UnicodeDomain() string
+	// ASCII is address with domain in ASCII form, it is used in SMTP commands
+	ASCII() string
+}
+
+// DomainToASCII converts domain to ASCII form by IDNA2008 lookup profile
+func DomainToASCII(domain string) (string, error) {
+	return idna.Lookup.ToASCII(domain)
+}
+
+// DomainToUnicode converts domain to Unicode form by IDNA2008 lookup profile
+func DomainToUnicode(domain string) (string, error) {
+	return idna.Lookup.ToUnicode(domain)
+}
+
+// IsASCII checks, whether s contains only ASCII characters
+func IsASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+// asciiDomain returns ASCII form of domain or domain itself if it is ASCII or can not be converted
+func asciiDomain(domain string) string {
+	if IsASCII(domain) {
+		return domain
+	}
+	if ascii, err := DomainToASCII(domain); err == nil {
+		return ascii
+	}
+
+	return domain
+}
+
+// unicodeDomain returns Unicode form of domain or domain itself if it can not be converted
+func unicodeDomain(domain string) string {
+	if unicode, err := DomainToUnicode(domain); err == nil {
+		return unicode
+	}
+
+	return domain
+}
+
+// ASCIIDomain returns domain of address in ASCII form
+func ASCIIDomain(addr Address) string {
+	if idnAddr, ok := addr.(IDNAddress); ok {
+		return idnAddr.ASCIIDomain()
+	}
+
+	return asciiDomain(addr.Domain())
+}
+
+// UnicodeDomain returns domain of address in Unicode form
+func UnicodeDomain(addr Address) string {
+	if idnAddr, ok := addr.(IDNAddress); ok {
+		return idnAddr.UnicodeDomain()
+	}
+
+	return unicodeDomain(addr.Domain())
+}
+
+// ASCIIAddress returns address with domain in ASCII form
+func ASCIIAddress(addr Address) string {
+	if idnAddr, ok := addr.(IDNAddress); ok {
+		return idnAddr.ASCII()
+	}
+
+	localPart, domain := SeparateEmail(addr.String())
+
+	return localPart + AT + asciiDomain(domain)
+}
+
+// RequiresSMTPUTF8 checks, whether local part of address is not ASCII, so SMTPUTF8 extension of server is required
+func RequiresSMTPUTF8(addr Address) bool {
+	if addr == nil {
+		return false
+	}
+
+	localPart, _ := SeparateEmail(addr.String())
+
+	return !IsASCII(localPart)
+}
+
+func (e address) ASCIIDomain() string {
+	return asciiDomain(e.domain)
+}
+
+func (e address) UnicodeDomain() string {
+	return unicodeDomain(e.domain)
+}
+
+func (e address) ASCII() string {
+	if !strings.Contains(e.source, AT) {
+		return e.source
+	}
+
+	localPart, domain := SeparateEmail(e.source)
+
+	return localPart + AT + asciiDomain(domain)
+}
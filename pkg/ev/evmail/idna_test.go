@@ -0,0 +1,143 @@
+package evmail_test
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDNAddress(t *testing.T) {
+	tests := []struct {
+		name              string
+		email             evmail.Address
+		wantASCIIDomain   string
+		wantUnicodeDomain string
+		wantASCII         string
+		wantSMTPUTF8      bool
+	}{
+		{
+			name:              "ascii",
+			email:             evmail.FromString("john@example.com"),
+			wantASCIIDomain:   "example.com",
+			wantUnicodeDomain: "example.com",
+			wantASCII:         "john@example.com",
+		},
+		{
+			name:              "unicode domain",
+			email:             evmail.FromString("john@Bücher.de"),
+			wantASCIIDomain:   "xn--bcher-kva.de",
+			wantUnicodeDomain: "bücher.de",
+			wantASCII:         "john@xn--bcher-kva.de",
+		},
+		{
+			name:              "punycode domain",
+			email:             evmail.NewEmailAddress("john", "xn--bcher-kva.de"),
+			wantASCIIDomain:   "xn--bcher-kva.de",
+			wantUnicodeDomain: "bücher.de",
+			wantASCII:         "john@xn--bcher-kva.de",
+		},
+		{
+			name:              "unicode local part",
+			email:             evmail.FromString("Йосиф@пример.рф"),
+			wantASCIIDomain:   "xn--e1afmkfd.xn--p1ai",
+			wantUnicodeDomain: "пример.рф",
+			wantASCII:         "йосиф@xn--e1afmkfd.xn--p1ai",
+			wantSMTPUTF8:      true,
+		},
+		{
+			name:              "invalid idn",
+			email:             evmail.FromString("john@a‍b.com"),
+			wantASCIIDomain:   "a‍b.com",
+			wantUnicodeDomain: "a‍b.com",
+			wantASCII:         "john@a‍b.com",
+		},
+		{
+			name:              "without at",
+			email:             evmail.FromString("john"),
+			wantASCIIDomain:   "",
+			wantUnicodeDomain: "",
+			wantASCII:         "john",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantASCIIDomain, evmail.ASCIIDomain(tt.email))
+			require.Equal(t, tt.wantUnicodeDomain, evmail.UnicodeDomain(tt.email))
+			require.Equal(t, tt.wantASCII, evmail.ASCIIAddress(tt.email))
+			require.Equal(t, tt.wantSMTPUTF8, evmail.RequiresSMTPUTF8(tt.email))
+		})
+	}
+}
+
+type plainAddress struct {
+	username, domain string
+}
+
+func (p plainAddress) Username() string { return p.username }
+func (p plainAddress) Domain() string   { return p.domain }
+func (p plainAddress) String() string   { return p.username + evmail.AT + p.domain }
+
+func TestIDNAddress_PlainAddress(t *testing.T) {
+	email := plainAddress{username: "josé", domain: "bücher.de"}
+
+	require.Equal(t, "xn--bcher-kva.de", evmail.ASCIIDomain(email))
+	require.Equal(t, "bücher.de", evmail.UnicodeDomain(email))
+	require.Equal(t, "josé@xn--bcher-kva.de", evmail.ASCIIAddress(email))
+	require.True(t, evmail.RequiresSMTPUTF8(email))
+	require.False(t, evmail.RequiresSMTPUTF8(nil))
+}
+
+func TestParse_Internationalized(t *testing.T) {
+	got, err := evmail.Parse(`"José Núñez"@Bücher.de`)
+	require.NoError(t, err)
+
+	require.Equal(t, "José Núñez", got.LocalPart())
+	require.Equal(t, "bücher.de", got.Domain())
+	require.Equal(t, "xn--bcher-kva.de", got.ASCIIDomain())
+	require.Equal(t, "bücher.de", got.UnicodeDomain())
+	require.Equal(t, `"José Núñez"@bücher.de`, got.Canonical())
+	require.Equal(t, `"José Núñez"@xn--bcher-kva.de`, got.ASCII())
+	require.True(t, evmail.RequiresSMTPUTF8(got))
+
+	got, err = evmail.Parse("δοκιμή(σχόλιο)@παράδειγμα.δοκιμή")
+	require.NoError(t, err)
+	require.Equal(t, "δοκιμή@παράδειγμα.δοκιμή", got.Canonical())
+	require.Equal(t, "δοκιμή@xn--hxajbheg2az3al.xn--jxalpdlp", got.ASCII())
+	require.Equal(t, []string{"σχόλιο"}, got.Comments())
+
+	got, err = evmail.Parse("john@[192.0.2.1]")
+	require.NoError(t, err)
+	require.Equal(t, "[192.0.2.1]", got.ASCIIDomain())
+}
+
+func TestParse_Internationalized_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		wantReason string
+		wantPos    int
+	}{
+		{name: "invalid utf-8 in local part", email: "jo\xffhn@example.com", wantReason: evmail.ParseReasonInvalidUTF8, wantPos: 2},
+		{name: "invalid utf-8 in quotes", email: "\"jo\xffhn\"@example.com", wantReason: evmail.ParseReasonInvalidUTF8, wantPos: 3},
+		{name: "invalid utf-8 in comment", email: "john(\xff)@example.com", wantReason: evmail.ParseReasonInvalidUTF8, wantPos: 5},
+		{name: "invalid idn", email: "john@a‍b.com", wantReason: evmail.ParseReasonInvalidIDN, wantPos: 5},
+		{name: "long ascii form of label", email: "john@" + "ü" + string(make([]rune, 0)) + longUnicodeLabel() + ".de", wantReason: evmail.ParseReasonLabelTooLong, wantPos: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evmail.Parse(tt.email)
+			require.Nil(t, got)
+			require.Equal(t, &evmail.ParseError{Reason: tt.wantReason, Pos: tt.wantPos}, err)
+		})
+	}
+}
+
+func longUnicodeLabel() string {
+	label := ""
+	for i := 0; i < 30; i++ {
+		label += "üa"
+	}
+
+	return label
+}
@@ -0,0 +1,539 @@
+package evmail
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"unicode/utf8"
+)
+
+// Length limits of RFC 5321 in octets
+const (
+	MaxLocalPartLength = 64
+	MaxAddressLength   = 254
+	MaxLabelLength     = 63
+)
+
+// IPv6Tag is tag of IPv6 address literals, e.g. [IPv6:2001:db8::1]
+const IPv6Tag = "IPv6:"
+
+// ParseErr is text for ParseError.Error
+const ParseErr = "ParseError"
+
+// Reasons of ParseError
+const (
+	ParseReasonEmpty             = "empty address"
+	ParseReasonMissingAt         = "missing @"
+	ParseReasonUnexpected        = "unexpected character"
+	ParseReasonEmptyLocalPart    = "empty local part"
+	ParseReasonEmptyDomain       = "empty domain"
+	ParseReasonEmptyLabel        = "empty label"
+	ParseReasonUnclosedQuote     = "unclosed quoted string"
+	ParseReasonUnclosedComment   = "unclosed comment"
+	ParseReasonUnclosedLiteral   = "unclosed domain literal"
+	ParseReasonInvalidLiteral    = "invalid address literal"
+	ParseReasonInvalidLabel      = "invalid domain label"
+	ParseReasonLocalPartTooLong  = "local part is too long"
+	ParseReasonLabelTooLong      = "domain label is too long"
+	ParseReasonAddressTooLong    = "address is too long"
+	ParseReasonInvalidQuotedPair = "invalid quoted pair"
+	ParseReasonInvalidUTF8       = "invalid UTF-8"
+	ParseReasonInvalidIDN        = "invalid internationalized domain name"
+)
+
+// ParseError is error of Parse, Pos is offset in bytes of the input
+type ParseError struct {
+	Reason string
+	Pos    int
+}
+
+func (p *ParseError) Error() string {
+	return fmt.Sprintf("%s: %s at position %d", ParseErr, p.Reason, p.Pos)
+}
+
+// ParsedAddress is Address parsed by Parse.
+// Username, Domain and String are in lower case like in other addresses of the package.
+type ParsedAddress interface {
+	IDNAddress
+	// Raw is input of Parse
+	Raw() string
+	// LocalPart is unquoted local part in original case
+	LocalPart() string
+	// Unquoted is address with unquoted local part without comments and folding whitespaces
+	Unquoted() string
+	// Canonical is address with local part quoted only if it is necessary and domain in lower case
+	Canonical() string
+	// Quoted is true for quoted local parts, e.g. "john doe"@domain.com
+	Quoted() bool
+	// IPLiteral is IP of address literal, e.g. [192.0.2.1], it is nil for domain names
+	IPLiteral() net.IP
+	// Comments are texts of comments without parentheses
+	Comments() []string
+}
+
+type parsedAddress struct {
+	raw         string
+	localPart   string
+	quoted      bool
+	domain      string
+	asciiDomain string
+	ip          net.IP
+	comments    []string
+}
+
+func (p parsedAddress) Username() string {
+	return strings.ToLower(p.localPart)
+}
+
+func (p parsedAddress) Domain() string {
+	return strings.ToLower(p.domain)
+}
+
+func (p parsedAddress) String() string {
+	return strings.ToLower(p.Canonical())
+}
+
+func (p parsedAddress) Raw() string {
+	return p.raw
+}
+
+func (p parsedAddress) LocalPart() string {
+	return p.localPart
+}
+
+func (p parsedAddress) Unquoted() string {
+	return p.localPart + AT + p.Domain()
+}
+
+func (p parsedAddress) Canonical() string {
+	return QuoteLocalPart(p.localPart) + AT + p.Domain()
+}
+
+func (p parsedAddress) ASCIIDomain() string {
+	return strings.ToLower(p.asciiDomain)
+}
+
+func (p parsedAddress) UnicodeDomain() string {
+	return unicodeDomain(p.Domain())
+}
+
+func (p parsedAddress) ASCII() string {
+	return QuoteLocalPart(p.localPart) + AT + p.ASCIIDomain()
+}
+
+func (p parsedAddress) Quoted() bool {
+	return p.quoted
+}
+
+func (p parsedAddress) IPLiteral() net.IP {
+	return p.ip
+}
+
+func (p parsedAddress) Comments() []string {
+	return p.comments
+}
+
+// QuoteLocalPart returns local part as dot-atom or quoted string if it is necessary
+func QuoteLocalPart(localPart string) string {
+	if isDotAtom(localPart) {
+		return localPart
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(localPart); i++ {
+		if c := localPart[i]; c == '"' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(localPart[i])
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// Parse parses addr-spec of RFC 5322 with quoted local parts, comments, folding whitespaces
+// and address literals of RFC 5321. UTF-8 is allowed by RFC 6532, internationalized domain names are validated by IDNA2008.
+// Limits of RFC 5321 are applied to the canonical form with ASCII domain.
+func Parse(email string) (ParsedAddress, error) {
+	p := &parser{s: email}
+
+	return p.parse()
+}
+
+type parser struct {
+	s        string
+	pos      int
+	comments []string
+}
+
+func (p *parser) errorf(pos int, reason string) error {
+	return &ParseError{Reason: reason, Pos: pos}
+}
+
+func (p *parser) unexpected() error {
+	return p.errorf(p.pos, fmt.Sprintf("%s %q", ParseReasonUnexpected, p.s[p.pos]))
+}
+
+func (p *parser) end() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *parser) peek() byte {
+	return p.s[p.pos]
+}
+
+func (p *parser) parse() (ParsedAddress, error) {
+	if strings.TrimSpace(p.s) == "" {
+		return nil, p.errorf(0, ParseReasonEmpty)
+	}
+
+	addr := parsedAddress{raw: p.s}
+	var err error
+
+	if err = p.cfws(); err != nil {
+		return nil, err
+	}
+	localStart := p.pos
+	switch {
+	case p.end():
+		return nil, p.errorf(p.pos, ParseReasonMissingAt)
+	case p.peek() == '"':
+		addr.quoted = true
+		addr.localPart, err = p.quotedString()
+	case p.peek() == '@':
+		return nil, p.errorf(p.pos, ParseReasonEmptyLocalPart)
+	default:
+		addr.localPart, err = p.dotAtom()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = p.cfws(); err != nil {
+		return nil, err
+	}
+
+	if p.end() {
+		return nil, p.errorf(p.pos, ParseReasonMissingAt)
+	}
+	if p.peek() != '@' {
+		return nil, p.unexpected()
+	}
+	p.pos++
+
+	if err = p.cfws(); err != nil {
+		return nil, err
+	}
+	domainStart := p.pos
+	switch {
+	case p.end():
+		return nil, p.errorf(p.pos, ParseReasonEmptyDomain)
+	case p.peek() == '[':
+		addr.domain, addr.ip, err = p.addressLiteral()
+		addr.asciiDomain = addr.domain
+	default:
+		addr.domain, addr.asciiDomain, err = p.domainName()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = p.cfws(); err != nil {
+		return nil, err
+	}
+	if !p.end() {
+		return nil, p.unexpected()
+	}
+
+	addr.comments = p.comments
+	if len(QuoteLocalPart(addr.localPart)) > MaxLocalPartLength {
+		return nil, p.errorf(localStart, ParseReasonLocalPartTooLong)
+	}
+	if len(addr.ASCII()) > MaxAddressLength {
+		return nil, p.errorf(domainStart, ParseReasonAddressTooLong)
+	}
+
+	return addr, nil
+}
+
+// cfws skips comments and folding whitespaces
+func (p *parser) cfws() error {
+	for !p.end() {
+		switch {
+		case p.fws():
+		case p.peek() == '(':
+			if err := p.comment(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// fws skips whitespace or CRLF followed by whitespace, it returns true if something was skipped
+func (p *parser) fws() bool {
+	switch {
+	case isWSP(p.peek()):
+		p.pos++
+		return true
+	case strings.HasPrefix(p.s[p.pos:], "\r\n") && p.pos+2 < len(p.s) && isWSP(p.s[p.pos+2]):
+		p.pos += 3
+		return true
+	}
+
+	return false
+}
+
+// comment parses nested comment and appends its text to comments
+func (p *parser) comment() error {
+	start := p.pos
+	depth := 0
+	var text strings.Builder
+	for !p.end() {
+		c := p.peek()
+		switch {
+		case c == '(':
+			if depth > 0 {
+				text.WriteByte(c)
+			}
+			depth++
+			p.pos++
+		case c == ')':
+			depth--
+			p.pos++
+			if depth == 0 {
+				p.comments = append(p.comments, text.String())
+				return nil
+			}
+			text.WriteByte(c)
+		case c == '\\':
+			pair, err := p.quotedPair()
+			if err != nil {
+				return err
+			}
+			text.WriteByte(pair)
+		case p.fws():
+			text.WriteByte(' ')
+		case isCText(c):
+			text.WriteByte(c)
+			p.pos++
+		case c >= utf8.RuneSelf:
+			r, err := p.utf8NonASCII()
+			if err != nil {
+				return err
+			}
+			text.WriteString(r)
+		default:
+			return p.unexpected()
+		}
+	}
+
+	return p.errorf(start, ParseReasonUnclosedComment)
+}
+
+// quotedPair parses "\" followed by visible character or whitespace
+func (p *parser) quotedPair() (byte, error) {
+	if p.pos+1 >= len(p.s) || !(isVChar(p.s[p.pos+1]) || isWSP(p.s[p.pos+1])) {
+		return 0, p.errorf(p.pos, ParseReasonInvalidQuotedPair)
+	}
+	p.pos += 2
+
+	return p.s[p.pos-1], nil
+}
+
+// quotedString parses quoted string and returns its unquoted content
+func (p *parser) quotedString() (string, error) {
+	start := p.pos
+	p.pos++
+	var content strings.Builder
+	for !p.end() {
+		c := p.peek()
+		switch {
+		case c == '"':
+			p.pos++
+			return content.String(), nil
+		case c == '\\':
+			pair, err := p.quotedPair()
+			if err != nil {
+				return "", err
+			}
+			content.WriteByte(pair)
+		case isWSP(c):
+			content.WriteByte(c)
+			p.pos++
+		case strings.HasPrefix(p.s[p.pos:], "\r\n") && p.pos+2 < len(p.s) && isWSP(p.s[p.pos+2]):
+			// CRLF of folding is removed
+			p.pos += 2
+		case isQText(c):
+			content.WriteByte(c)
+			p.pos++
+		case c >= utf8.RuneSelf:
+			r, err := p.utf8NonASCII()
+			if err != nil {
+				return "", err
+			}
+			content.WriteString(r)
+		default:
+			return "", p.unexpected()
+		}
+	}
+
+	return "", p.errorf(start, ParseReasonUnclosedQuote)
+}
+
+// dotAtom parses dot-atom-text
+func (p *parser) dotAtom() (string, error) {
+	start := p.pos
+	for {
+		atomStart := p.pos
+		for !p.end() {
+			if isAText(p.peek()) {
+				p.pos++
+				continue
+			}
+			if p.peek() < utf8.RuneSelf {
+				break
+			}
+			if _, err := p.utf8NonASCII(); err != nil {
+				return "", err
+			}
+		}
+		if p.pos == atomStart {
+			if p.pos == start && !p.end() && p.peek() != '.' {
+				return "", p.unexpected()
+			}
+			return "", p.errorf(p.pos, ParseReasonEmptyLabel)
+		}
+		if p.end() || p.peek() != '.' {
+			return p.s[start:p.pos], nil
+		}
+		p.pos++
+	}
+}
+
+// domainName parses dot-atom, converts it to ASCII form by IDNA2008 and checks labels by RFC 5321
+func (p *parser) domainName() (string, string, error) {
+	start := p.pos
+	domain, err := p.dotAtom()
+	if err != nil {
+		return "", "", err
+	}
+
+	ascii := domain
+	if !IsASCII(domain) {
+		if ascii, err = DomainToASCII(domain); err != nil {
+			return "", "", p.errorf(start, ParseReasonInvalidIDN)
+		}
+	}
+
+	// positions of labels are known only if labels were not changed by IDNA mapping
+	labels, asciiLabels := strings.Split(domain, "."), strings.Split(ascii, ".")
+	samePositions := len(labels) == len(asciiLabels)
+	labelStart := start
+	for i, label := range asciiLabels {
+		pos := start
+		if samePositions {
+			pos = labelStart
+			labelStart += len(labels[i]) + 1
+		}
+
+		if len(label) > MaxLabelLength {
+			return "", "", p.errorf(pos, ParseReasonLabelTooLong)
+		}
+		for j := 0; j < len(label); j++ {
+			c := label[j]
+			if isLetDig(c) || c == '-' && j > 0 && j < len(label)-1 {
+				continue
+			}
+			if samePositions && label == labels[i] {
+				pos += j
+			}
+			return "", "", p.errorf(pos, ParseReasonInvalidLabel)
+		}
+	}
+
+	return domain, ascii, nil
+}
+
+// utf8NonASCII parses non-ASCII UTF-8 character of RFC 6532
+func (p *parser) utf8NonASCII() (string, error) {
+	r, size := utf8.DecodeRuneInString(p.s[p.pos:])
+	if r == utf8.RuneError && size <= 1 {
+		return "", p.errorf(p.pos, ParseReasonInvalidUTF8)
+	}
+	p.pos += size
+
+	return p.s[p.pos-size : p.pos], nil
+}
+
+// addressLiteral parses IPv4 or IPv6 address literal of RFC 5321
+func (p *parser) addressLiteral() (string, net.IP, error) {
+	start := p.pos
+	end := strings.IndexByte(p.s[start:], ']')
+	if end < 0 {
+		return "", nil, p.errorf(start, ParseReasonUnclosedLiteral)
+	}
+	content := p.s[start+1 : start+end]
+
+	var ip net.IP
+	if len(content) >= len(IPv6Tag) && strings.EqualFold(content[:len(IPv6Tag)], IPv6Tag) {
+		ip = net.ParseIP(content[len(IPv6Tag):])
+		if ip == nil || !strings.Contains(content[len(IPv6Tag):], ":") {
+			return "", nil, p.errorf(start+1, ParseReasonInvalidLiteral)
+		}
+		content = IPv6Tag + ip.String()
+	} else {
+		ip = net.ParseIP(content).To4()
+		if ip == nil || strings.Contains(content, ":") {
+			return "", nil, p.errorf(start+1, ParseReasonInvalidLiteral)
+		}
+		content = ip.String()
+	}
+	p.pos = start + end + 1
+
+	return "[" + content + "]", ip, nil
+}
+
+func isDotAtom(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, atom := range strings.Split(s, ".") {
+		if atom == "" {
+			return false
+		}
+		for i := 0; i < len(atom); i++ {
+			if !isAText(atom[i]) && atom[i] < utf8.RuneSelf {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isWSP(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+func isVChar(c byte) bool {
+	return c >= 33 && c <= 126
+}
+
+func isLetDig(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isAText(c byte) bool {
+	return isLetDig(c) || strings.IndexByte("!#$%&'*+-/=?^_`{|}~", c) >= 0
+}
+
+func isQText(c byte) bool {
+	return c == 33 || c >= 35 && c <= 91 || c >= 93 && c <= 126
+}
+
+func isCText(c byte) bool {
+	return c >= 33 && c <= 39 || c >= 42 && c <= 91 || c >= 93 && c <= 126
+}
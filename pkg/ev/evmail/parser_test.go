@@ -0,0 +1,193 @@
+package evmail_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		email         string
+		wantLocalPart string
+		wantDomain    string
+		wantUnquoted  string
+		wantCanonical string
+		wantString    string
+		wantQuoted    bool
+		wantIP        net.IP
+		wantComments  []string
+	}{
+		{
+			name:          "dot atom",
+			email:         "John.Doe@Example.COM",
+			wantLocalPart: "John.Doe",
+			wantDomain:    "example.com",
+			wantUnquoted:  "John.Doe@example.com",
+			wantCanonical: "John.Doe@example.com",
+			wantString:    "john.doe@example.com",
+		},
+		{
+			name:          "quoted at",
+			email:         `"a@b"@example.com`,
+			wantLocalPart: "a@b",
+			wantDomain:    "example.com",
+			wantUnquoted:  "a@b@example.com",
+			wantCanonical: `"a@b"@example.com`,
+			wantString:    `"a@b"@example.com`,
+			wantQuoted:    true,
+		},
+		{
+			name:          "unnecessary quotes",
+			email:         `"john.doe"@example.com`,
+			wantLocalPart: "john.doe",
+			wantDomain:    "example.com",
+			wantUnquoted:  "john.doe@example.com",
+			wantCanonical: "john.doe@example.com",
+			wantString:    "john.doe@example.com",
+			wantQuoted:    true,
+		},
+		{
+			name:          "quoted pairs and spaces",
+			email:         `"john \"the\" \\ doe"@example.com`,
+			wantLocalPart: `john "the" \ doe`,
+			wantDomain:    "example.com",
+			wantUnquoted:  `john "the" \ doe@example.com`,
+			wantCanonical: `"john \"the\" \\ doe"@example.com`,
+			wantString:    `"john \"the\" \\ doe"@example.com`,
+			wantQuoted:    true,
+		},
+		{
+			name:          "comments and folding whitespaces",
+			email:         " (first (nested)) john(second)@(third)\r\n example.com (last) ",
+			wantLocalPart: "john",
+			wantDomain:    "example.com",
+			wantUnquoted:  "john@example.com",
+			wantCanonical: "john@example.com",
+			wantString:    "john@example.com",
+			wantComments:  []string{"first (nested)", "second", "third", "last"},
+		},
+		{
+			name:          "ipv4 literal",
+			email:         "john@[192.0.2.1]",
+			wantLocalPart: "john",
+			wantDomain:    "[192.0.2.1]",
+			wantUnquoted:  "john@[192.0.2.1]",
+			wantCanonical: "john@[192.0.2.1]",
+			wantString:    "john@[192.0.2.1]",
+			wantIP:        net.ParseIP("192.0.2.1").To4(),
+		},
+		{
+			name:          "ipv6 literal",
+			email:         "john@[IPv6:2001:DB8:0:0:0:0:0:1]",
+			wantLocalPart: "john",
+			wantDomain:    "[ipv6:2001:db8::1]",
+			wantUnquoted:  "john@[ipv6:2001:db8::1]",
+			wantCanonical: "john@[ipv6:2001:db8::1]",
+			wantString:    "john@[ipv6:2001:db8::1]",
+			wantIP:        net.ParseIP("2001:db8::1"),
+		},
+		{
+			name:          "special characters",
+			email:         "!#$%&'*+-/=?^_`{|}~@a-b.example",
+			wantLocalPart: "!#$%&'*+-/=?^_`{|}~",
+			wantDomain:    "a-b.example",
+			wantUnquoted:  "!#$%&'*+-/=?^_`{|}~@a-b.example",
+			wantCanonical: "!#$%&'*+-/=?^_`{|}~@a-b.example",
+			wantString:    "!#$%&'*+-/=?^_`{|}~@a-b.example",
+		},
+		{
+			name:          "limits",
+			email:         strings.Repeat("a", 64) + "@" + strings.Repeat("b", 63) + "." + strings.Repeat("c", 63) + "." + strings.Repeat("d", 61),
+			wantLocalPart: strings.Repeat("a", 64),
+			wantDomain:    strings.Repeat("b", 63) + "." + strings.Repeat("c", 63) + "." + strings.Repeat("d", 61),
+			wantUnquoted:  strings.Repeat("a", 64) + "@" + strings.Repeat("b", 63) + "." + strings.Repeat("c", 63) + "." + strings.Repeat("d", 61),
+			wantCanonical: strings.Repeat("a", 64) + "@" + strings.Repeat("b", 63) + "." + strings.Repeat("c", 63) + "." + strings.Repeat("d", 61),
+			wantString:    strings.Repeat("a", 64) + "@" + strings.Repeat("b", 63) + "." + strings.Repeat("c", 63) + "." + strings.Repeat("d", 61),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evmail.Parse(tt.email)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.email, got.Raw())
+			require.Equal(t, tt.wantLocalPart, got.LocalPart())
+			require.Equal(t, strings.ToLower(tt.wantLocalPart), got.Username())
+			require.Equal(t, tt.wantDomain, got.Domain())
+			require.Equal(t, tt.wantUnquoted, got.Unquoted())
+			require.Equal(t, tt.wantCanonical, got.Canonical())
+			require.Equal(t, tt.wantString, got.String())
+			require.Equal(t, tt.wantQuoted, got.Quoted())
+			require.Equal(t, tt.wantIP, got.IPLiteral())
+			require.Equal(t, tt.wantComments, got.Comments())
+		})
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		wantReason string
+		wantPos    int
+	}{
+		{name: "empty", email: " ", wantReason: evmail.ParseReasonEmpty, wantPos: 0},
+		{name: "without at", email: "john", wantReason: evmail.ParseReasonMissingAt, wantPos: 4},
+		{name: "only comment", email: "(comment)", wantReason: evmail.ParseReasonMissingAt, wantPos: 9},
+		{name: "empty local part", email: "@example.com", wantReason: evmail.ParseReasonEmptyLocalPart, wantPos: 0},
+		{name: "empty domain", email: "john@", wantReason: evmail.ParseReasonEmptyDomain, wantPos: 5},
+		{name: "unquoted at", email: "a@b@example.com", wantReason: evmail.ParseReasonUnexpected + ` '@'`, wantPos: 3},
+		{name: "leading dot", email: ".john@example.com", wantReason: evmail.ParseReasonEmptyLabel, wantPos: 0},
+		{name: "double dot", email: "john..doe@example.com", wantReason: evmail.ParseReasonEmptyLabel, wantPos: 5},
+		{name: "trailing dot", email: "john.@example.com", wantReason: evmail.ParseReasonEmptyLabel, wantPos: 5},
+		{name: "trailing dot of domain", email: "john@example.com.", wantReason: evmail.ParseReasonEmptyLabel, wantPos: 17},
+		{name: "space", email: "john doe@example.com", wantReason: evmail.ParseReasonUnexpected + ` 'd'`, wantPos: 5},
+		{name: "unexpected in local part", email: "john<@example.com", wantReason: evmail.ParseReasonUnexpected + ` '<'`, wantPos: 4},
+		{name: "unclosed quote", email: `"john@example.com`, wantReason: evmail.ParseReasonUnclosedQuote, wantPos: 0},
+		{name: "unclosed comment", email: "john(comment@example.com", wantReason: evmail.ParseReasonUnclosedComment, wantPos: 4},
+		{name: "invalid quoted pair", email: "\"john\\\x01\"@example.com", wantReason: evmail.ParseReasonInvalidQuotedPair, wantPos: 5},
+		{name: "control in quotes", email: "\"john\x01\"@example.com", wantReason: evmail.ParseReasonUnexpected + ` '\x01'`, wantPos: 5},
+		{name: "unclosed literal", email: "john@[192.0.2.1", wantReason: evmail.ParseReasonUnclosedLiteral, wantPos: 5},
+		{name: "invalid ipv4 literal", email: "john@[192.0.2.256]", wantReason: evmail.ParseReasonInvalidLiteral, wantPos: 6},
+		{name: "ipv6 without tag", email: "john@[2001:db8::1]", wantReason: evmail.ParseReasonInvalidLiteral, wantPos: 6},
+		{name: "ipv4 with ipv6 tag", email: "john@[IPv6:192.0.2.1]", wantReason: evmail.ParseReasonInvalidLiteral, wantPos: 6},
+		{name: "hyphen at start of label", email: "john@-example.com", wantReason: evmail.ParseReasonInvalidLabel, wantPos: 5},
+		{name: "hyphen at end of label", email: "john@example-.com", wantReason: evmail.ParseReasonInvalidLabel, wantPos: 12},
+		{name: "underscore in domain", email: "john@ex_ample.com", wantReason: evmail.ParseReasonInvalidLabel, wantPos: 7},
+		{name: "long label", email: "john@" + strings.Repeat("a", 64) + ".com", wantReason: evmail.ParseReasonLabelTooLong, wantPos: 5},
+		{name: "long local part", email: strings.Repeat("a", 65) + "@example.com", wantReason: evmail.ParseReasonLocalPartTooLong, wantPos: 0},
+		{name: "long quoted local part", email: `"` + strings.Repeat("a", 62) + ` "@example.com`, wantReason: evmail.ParseReasonLocalPartTooLong, wantPos: 0},
+		{
+			name:       "long address",
+			email:      "john@" + strings.Repeat(strings.Repeat("a", 63)+".", 3) + strings.Repeat("a", 59),
+			wantReason: evmail.ParseReasonAddressTooLong,
+			wantPos:    5,
+		},
+		{name: "trailing text", email: "john@example.com x", wantReason: evmail.ParseReasonUnexpected + ` 'x'`, wantPos: 17},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evmail.Parse(tt.email)
+			require.Nil(t, got)
+			require.Equal(t, &evmail.ParseError{Reason: tt.wantReason, Pos: tt.wantPos}, err)
+		})
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	err := &evmail.ParseError{Reason: evmail.ParseReasonMissingAt, Pos: 4}
+
+	require.Equal(t, "ParseError: missing @ at position 4", err.Error())
+}
+
+func TestQuoteLocalPart(t *testing.T) {
+	require.Equal(t, "john.doe", evmail.QuoteLocalPart("john.doe"))
+	require.Equal(t, `"john..doe"`, evmail.QuoteLocalPart("john..doe"))
+	require.Equal(t, `""`, evmail.QuoteLocalPart(""))
+	require.Equal(t, `"a\"b"`, evmail.QuoteLocalPart(`a"b`))
+}
@@ -0,0 +1,49 @@
+package evmetrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+)
+
+// NewDecorator instantiates Decorator, name is label of validator in metrics
+func NewDecorator(validator ev.Validator, name ev.ValidatorName, metrics *Metrics) ev.Validator {
+	return &Decorator{
+		Validator: validator,
+		Name:      name,
+		Metrics:   metrics,
+	}
+}
+
+// Decorator observes outcomes and latencies of Validator
+type Decorator struct {
+	Validator ev.Validator
+	Name      ev.ValidatorName
+	Metrics   *Metrics
+}
+
+func (d *Decorator) GetDeps() []ev.ValidatorName {
+	return d.Validator.GetDeps()
+}
+
+func (d *Decorator) Validate(input ev.Input, results ...ev.ValidationResult) ev.ValidationResult {
+	return d.ValidateContext(context.Background(), input, results...)
+}
+
+func (d *Decorator) ValidateContext(ctx context.Context, input ev.Input, results ...ev.ValidationResult) ev.ValidationResult {
+	start := time.Now()
+	result := ev.ValidateContext(ctx, d.Validator, input, results...)
+	d.Metrics.ObserveValidation(d.Name, result, time.Since(start))
+
+	return result
+}
+
+// Instrument wraps all validators of builder by Decorator with their names
+func (m *Metrics) Instrument(builder *ev.DepBuilder) *ev.DepBuilder {
+	for name, validator := range builder.Validators {
+		builder.Set(name, NewDecorator(validator, name, m))
+	}
+
+	return builder
+}
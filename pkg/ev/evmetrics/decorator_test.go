@@ -0,0 +1,60 @@
+package evmetrics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmetrics"
+	"github.com/prodadidb/go-email-validator/pkg/ev/free"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type depsValidator struct {
+	ev.AValidatorWithoutDeps
+	deps []ev.ValidatorName
+}
+
+func (d depsValidator) GetDeps() []ev.ValidatorName {
+	return d.deps
+}
+
+func (d depsValidator) Validate(_ ev.Input, _ ...ev.ValidationResult) ev.ValidationResult {
+	return ev.NewResultWithOutcome(ev.OutcomeUnknown, nil, nil, ev.OtherValidator)
+}
+
+func TestDecorator(t *testing.T) {
+	metrics := evmetrics.NewMetrics(evmetrics.MetricsDTO{})
+	registry := newRegistry(t, metrics)
+	deps := []ev.ValidatorName{ev.SyntaxValidatorName}
+
+	validator := evmetrics.NewDecorator(depsValidator{deps: deps}, ev.SMTPValidatorName, metrics)
+	require.Equal(t, deps, validator.GetDeps())
+
+	result := ev.ValidateContext(context.Background(), validator, ev.NewInput(evmail.FromString("user@domain.com")))
+	require.Equal(t, ev.OutcomeUnknown, result.Outcome())
+	result = validator.Validate(ev.NewInput(evmail.FromString("user@domain.com")))
+	require.Equal(t, ev.OutcomeUnknown, result.Outcome())
+
+	count, err := testutil.GatherAndCount(registry, "email_validator_validations_total")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	require.Equal(t, 2.0, testutil.ToFloat64(metrics.Validations(ev.SMTPValidatorName, ev.OutcomeUnknown)))
+}
+
+func TestMetrics_Instrument(t *testing.T) {
+	metrics := evmetrics.NewMetrics(evmetrics.MetricsDTO{})
+	builder := metrics.Instrument(ev.NewDepBuilder(ev.ValidatorMap{
+		ev.SyntaxValidatorName: ev.NewSyntaxValidator(),
+		ev.FreeValidatorName:   ev.NewFreeValidator(free.NewWillWhiteSetFree()),
+	}))
+	require.IsType(t, &evmetrics.Decorator{}, builder.Get(ev.SyntaxValidatorName))
+
+	result := builder.Build().Validate(ev.NewInput(evmail.FromString("user@gmail.com")))
+	require.False(t, result.IsValid())
+
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.Validations(ev.SyntaxValidatorName, ev.OutcomeValid)))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.Validations(ev.FreeValidatorName, ev.OutcomeInvalid)))
+}
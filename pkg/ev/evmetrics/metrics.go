@@ -0,0 +1,204 @@
+package evmetrics
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/publicsuffix"
+)
+
+// DefaultNamespace is namespace of metrics
+const DefaultNamespace = "email_validator"
+
+// RandomRCPTCache is label of cache of evsmtp.CheckerCacheRandomRCPTStruct
+const RandomRCPTCache = "randomRCPT"
+
+// Labels of SMTP replies without code
+const (
+	// ReplySuccess is label of successful stage, net/smtp does not expose codes of positive replies
+	ReplySuccess = "2xx"
+	// ReplyNone is label of stage failed without reply, e.g. by timeout
+	ReplyNone = "none"
+)
+
+// replyStages are stages with commands, which are replied by server
+var replyStages = map[evsmtp.SendMailStage]bool{
+	evsmtp.HelloStage:      true,
+	evsmtp.MailStage:       true,
+	evsmtp.RandomRCPTStage: true,
+	evsmtp.RCPTsStage:      true,
+	evsmtp.QuitStage:       true,
+}
+
+// ProviderGetter returns provider of MX host, it is used as label of SMTP replies
+type ProviderGetter func(mx string) string
+
+// DefaultProvider returns registrable domain of MX host, e.g. google.com for gmail-smtp-in.l.google.com
+func DefaultProvider(mx string) string {
+	mx = strings.ToLower(strings.TrimSuffix(mx, "."))
+	if provider, err := publicsuffix.EffectiveTLDPlusOne(mx); err == nil {
+		return provider
+	}
+
+	return mx
+}
+
+// MetricsDTO is DTO for NewMetrics
+type MetricsDTO struct {
+	// Namespace is DefaultNamespace if it is empty
+	Namespace string
+	// Buckets are buckets of latency histograms in seconds, prometheus.DefBuckets is used if it is empty
+	Buckets []float64
+	// Provider is DefaultProvider if nil
+	Provider ProviderGetter
+}
+
+// NewMetrics instantiates Metrics, it should be registered in prometheus.Registerer
+func NewMetrics(dto MetricsDTO) *Metrics {
+	if dto.Namespace == "" {
+		dto.Namespace = DefaultNamespace
+	}
+	if len(dto.Buckets) == 0 {
+		dto.Buckets = prometheus.DefBuckets
+	}
+	if dto.Provider == nil {
+		dto.Provider = DefaultProvider
+	}
+
+	return &Metrics{
+		provider: dto.Provider,
+		validations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: dto.Namespace,
+			Name:      "validations_total",
+			Help:      "Number of validations by validator and outcome.",
+		}, []string{"validator", "outcome"}),
+		validationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: dto.Namespace,
+			Name:      "validation_duration_seconds",
+			Help:      "Latency of validations by validator.",
+			Buckets:   dto.Buckets,
+		}, []string{"validator"}),
+		stageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: dto.Namespace,
+			Subsystem: "smtp",
+			Name:      "stage_duration_seconds",
+			Help:      "Latency of stages of SMTP validation.",
+			Buckets:   dto.Buckets,
+		}, []string{"stage"}),
+		replies: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: dto.Namespace,
+			Subsystem: "smtp",
+			Name:      "replies_total",
+			Help:      "Number of SMTP replies by provider of MX host, stage and reply code.",
+		}, []string{"provider", "stage", "code"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: dto.Namespace,
+			Name:      "cache_requests_total",
+			Help:      "Number of cache lookups by cache and result.",
+		}, []string{"cache", "result"}),
+	}
+}
+
+// Metrics are Prometheus collectors of validations, SMTP stages and caches
+type Metrics struct {
+	provider           ProviderGetter
+	validations        *prometheus.CounterVec
+	validationDuration *prometheus.HistogramVec
+	stageDuration      *prometheus.HistogramVec
+	replies            *prometheus.CounterVec
+	cache              *prometheus.CounterVec
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.validations, m.validationDuration, m.stageDuration, m.replies, m.cache}
+}
+
+// Describe implements prometheus.Collector
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range m.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range m.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+// ObserveValidation counts result of validator and its latency
+func (m *Metrics) ObserveValidation(name ev.ValidatorName, result ev.ValidationResult, duration time.Duration) {
+	outcome := ev.OutcomeUnknown
+	if result != nil {
+		outcome = result.Outcome()
+	}
+
+	m.validations.WithLabelValues(string(name), outcome.String()).Inc()
+	m.validationDuration.WithLabelValues(string(name)).Observe(duration.Seconds())
+}
+
+// Validations returns counter of validations of validator with outcome
+func (m *Metrics) Validations(name ev.ValidatorName, outcome ev.Outcome) prometheus.Counter {
+	return m.validations.WithLabelValues(string(name), outcome.String())
+}
+
+// ObserveStage observes latency of stage and reply of server
+func (m *Metrics) ObserveStage(event evsmtp.StageEvent) {
+	stage := evsmtp.StageName(event.Stage)
+	m.stageDuration.WithLabelValues(stage).Observe(event.Duration.Seconds())
+
+	if event.MX == "" || !replyStages[event.Stage] {
+		return
+	}
+	m.replies.WithLabelValues(m.provider(event.MX), stage, replyCode(event.Err)).Inc()
+}
+
+// ObserveCache counts hit or miss of cache
+func (m *Metrics) ObserveCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	m.cache.WithLabelValues(cache, result).Inc()
+}
+
+// CacheHook returns callback for ev.CacheDecorator.OnCache
+func (m *Metrics) CacheHook(cache string) func(ctx context.Context, hit bool) {
+	return func(_ context.Context, hit bool) {
+		m.ObserveCache(cache, hit)
+	}
+}
+
+// SMTPHooks returns evsmtp.Hooks for evsmtp.CheckerDTO, cache of random RCPT is labeled by RandomRCPTCache
+func (m *Metrics) SMTPHooks() *evsmtp.Hooks {
+	return &evsmtp.Hooks{
+		Stage: func(_ context.Context, event evsmtp.StageEvent) {
+			m.ObserveStage(event)
+		},
+		Cache: func(hit bool) {
+			m.ObserveCache(RandomRCPTCache, hit)
+		},
+	}
+}
+
+func replyCode(err error) string {
+	if err == nil {
+		return ReplySuccess
+	}
+
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return strconv.Itoa(protoErr.Code)
+	}
+
+	return ReplyNone
+}
@@ -0,0 +1,104 @@
+package evmetrics_test
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmetrics"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newRegistry(t *testing.T, metrics *evmetrics.Metrics) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(metrics))
+
+	return registry
+}
+
+func TestDefaultProvider(t *testing.T) {
+	tests := []struct {
+		mx   string
+		want string
+	}{
+		{mx: "Gmail-SMTP-In.L.Google.com.", want: "google.com"},
+		{mx: "mx1.mail.yahoo.co.jp", want: "yahoo.co.jp"},
+		{mx: "localhost", want: "localhost"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mx, func(t *testing.T) {
+			require.Equal(t, tt.want, evmetrics.DefaultProvider(tt.mx))
+		})
+	}
+}
+
+func TestMetrics_ObserveValidation(t *testing.T) {
+	metrics := evmetrics.NewMetrics(evmetrics.MetricsDTO{})
+	registry := newRegistry(t, metrics)
+
+	metrics.ObserveValidation(ev.SyntaxValidatorName, ev.NewValidResult(ev.SyntaxValidatorName), time.Millisecond)
+	metrics.ObserveValidation(ev.SyntaxValidatorName, ev.NewResult(false, nil, nil, ev.SyntaxValidatorName), time.Millisecond)
+	metrics.ObserveValidation(ev.SyntaxValidatorName, ev.NewValidResult(ev.SyntaxValidatorName), time.Millisecond)
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP email_validator_validations_total Number of validations by validator and outcome.
+# TYPE email_validator_validations_total counter
+email_validator_validations_total{outcome="invalid",validator="syntaxValidator"} 1
+email_validator_validations_total{outcome="valid",validator="syntaxValidator"} 2
+`), "email_validator_validations_total"))
+
+	count, err := testutil.GatherAndCount(registry, "email_validator_validation_duration_seconds")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestMetrics_ObserveStage(t *testing.T) {
+	metrics := evmetrics.NewMetrics(evmetrics.MetricsDTO{Namespace: "test"})
+	registry := newRegistry(t, metrics)
+	hooks := metrics.SMTPHooks()
+	ctx := context.Background()
+
+	mx := "alt1.gmail-smtp-in.l.google.com."
+	hooks.Stage(ctx, evsmtp.StageEvent{MX: mx, Stage: evsmtp.ConnectionStage, Duration: time.Second})
+	hooks.Stage(ctx, evsmtp.StageEvent{MX: mx, Stage: evsmtp.HelloStage})
+	hooks.Stage(ctx, evsmtp.StageEvent{MX: mx, Stage: evsmtp.RCPTsStage, Err: evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550})})
+	hooks.Stage(ctx, evsmtp.StageEvent{MX: "gmail-smtp-in.l.google.com", Stage: evsmtp.RCPTsStage, Err: evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550})})
+	hooks.Stage(ctx, evsmtp.StageEvent{MX: mx, Stage: evsmtp.QuitStage, Err: evsmtp.NewError(evsmtp.QuitStage, errors.New("timeout"))})
+	hooks.Stage(ctx, evsmtp.StageEvent{Stage: evsmtp.ConnectionStage, Err: evsmtp.ErrConnection})
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP test_smtp_replies_total Number of SMTP replies by provider of MX host, stage and reply code.
+# TYPE test_smtp_replies_total counter
+test_smtp_replies_total{code="2xx",provider="google.com",stage="hello"} 1
+test_smtp_replies_total{code="550",provider="google.com",stage="rcpts"} 2
+test_smtp_replies_total{code="none",provider="google.com",stage="quit"} 1
+`), "test_smtp_replies_total"))
+
+	count, err := testutil.GatherAndCount(registry, "test_smtp_stage_duration_seconds")
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+}
+
+func TestMetrics_ObserveCache(t *testing.T) {
+	metrics := evmetrics.NewMetrics(evmetrics.MetricsDTO{})
+	registry := newRegistry(t, metrics)
+
+	metrics.CacheHook(string(ev.MXValidatorName))(context.Background(), true)
+	metrics.CacheHook(string(ev.MXValidatorName))(context.Background(), false)
+	metrics.SMTPHooks().Cache(true)
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP email_validator_cache_requests_total Number of cache lookups by cache and result.
+# TYPE email_validator_cache_requests_total counter
+email_validator_cache_requests_total{cache="MXValidator",result="hit"} 1
+email_validator_cache_requests_total{cache="MXValidator",result="miss"} 1
+email_validator_cache_requests_total{cache="randomRCPT",result="hit"} 1
+`), "email_validator_cache_requests_total"))
+}
@@ -0,0 +1,62 @@
+package evotel
+
+import (
+	"context"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewDecorator instantiates Decorator, name is name of span
+func NewDecorator(validator ev.Validator, name ev.ValidatorName, tracing *Tracing) ev.Validator {
+	return &Decorator{
+		Validator: validator,
+		Name:      name,
+		Tracing:   tracing,
+	}
+}
+
+// Decorator creates span for Validator, spans of nested validators and SMTP stages are its children
+type Decorator struct {
+	Validator ev.Validator
+	Name      ev.ValidatorName
+	Tracing   *Tracing
+}
+
+func (d *Decorator) GetDeps() []ev.ValidatorName {
+	return d.Validator.GetDeps()
+}
+
+func (d *Decorator) Validate(input ev.Input, results ...ev.ValidationResult) ev.ValidationResult {
+	return d.ValidateContext(context.Background(), input, results...)
+}
+
+func (d *Decorator) ValidateContext(ctx context.Context, input ev.Input, results ...ev.ValidationResult) ev.ValidationResult {
+	attrs := append(d.Tracing.emailAttributes(input.Email()), ValidatorKey.String(string(d.Name)))
+	ctx, span := d.Tracing.tracer.Start(ctx, string(d.Name), trace.WithAttributes(attrs...))
+	defer span.End()
+
+	result := ev.ValidateContext(ctx, d.Validator, input, results...)
+	d.Tracing.endValidation(span, result)
+
+	return result
+}
+
+// Instrument wraps all validators of builder by Decorator with their names
+func (t *Tracing) Instrument(builder *ev.DepBuilder) *ev.DepBuilder {
+	for name, validator := range builder.Validators {
+		builder.Set(name, NewDecorator(validator, name, t))
+	}
+
+	return builder
+}
+
+// Build instruments validators of builder and wraps built DepValidator by Decorator with ev.DepValidatorName
+func (t *Tracing) Build(builder *ev.DepBuilder) (ev.Validator, error) {
+	validator, err := t.Instrument(builder).BuildE()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDecorator(validator, ev.DepValidatorName, t), nil
+}
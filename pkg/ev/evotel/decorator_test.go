@@ -0,0 +1,127 @@
+package evotel_test
+
+import (
+	"context"
+	"io"
+	"net/smtp"
+	"net/textproto"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evotel"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp/smtpclient"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// rejectingSendMail accepts all commands, but rejects all recipients
+type rejectingSendMail struct{}
+
+func (rejectingSendMail) Client() smtpclient.SMTPClient { return nil }
+func (rejectingSendMail) Hello(string) error            { return nil }
+func (rejectingSendMail) Auth(smtp.Auth) error          { return nil }
+func (rejectingSendMail) Mail(string) error             { return nil }
+func (rejectingSendMail) RCPTs(addrs []string) map[string]error {
+	return map[string]error{addrs[0]: &textproto.Error{Code: 550, Msg: addrs[0] + " unknown"}}
+}
+func (rejectingSendMail) Data() (io.WriteCloser, error)      { return nil, nil }
+func (rejectingSendMail) Write(io.WriteCloser, []byte) error { return nil }
+func (rejectingSendMail) Quit() error                        { return nil }
+func (rejectingSendMail) Close() error                       { return nil }
+
+func newTracedValidator(t *testing.T, tracing *evotel.Tracing) ev.Validator {
+	checker := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		SendMailFactory: func(context.Context, string, evsmtp.Options) (evsmtp.SendMail, error) {
+			return rejectingSendMail{}, nil
+		},
+		RandomEmail: func(domain string) (evmail.Address, error) {
+			return evmail.NewEmailAddress("random", domain), nil
+		},
+		Hooks: tracing.SMTPHooks(),
+	})
+	lookupMX := func(context.Context, string) (evsmtp.MXs, error) {
+		return evsmtp.MXs{{Host: "mx.domain.com."}}, nil
+	}
+
+	validator, err := tracing.Build(ev.NewDepBuilder(ev.ValidatorMap{
+		ev.SyntaxValidatorName: ev.NewSyntaxValidator(),
+		ev.MXValidatorName:     ev.NewMXValidatorContext(tracing.LookupMX(lookupMX)),
+		ev.SMTPValidatorName:   ev.NewSMTPValidator(checker),
+	}))
+	require.NoError(t, err)
+
+	return validator
+}
+
+func TestTracing_Build(t *testing.T) {
+	r := &recorder{}
+	tracing := evotel.NewTracing(evotel.TracingDTO{TracerProvider: r})
+
+	result := newTracedValidator(t, tracing).Validate(ev.NewInput(evmail.FromString("john.doe@domain.com")))
+	require.Equal(t, ev.OutcomeInvalid, result.Outcome())
+
+	tests := []struct {
+		name       string
+		wantParent string
+	}{
+		{name: string(ev.DepValidatorName), wantParent: ""},
+		{name: string(ev.SyntaxValidatorName), wantParent: string(ev.DepValidatorName)},
+		{name: string(ev.MXValidatorName), wantParent: string(ev.DepValidatorName)},
+		{name: evotel.MXLookupSpan, wantParent: string(ev.MXValidatorName)},
+		{name: string(ev.SMTPValidatorName), wantParent: string(ev.DepValidatorName)},
+		{name: "smtp connection", wantParent: string(ev.SMTPValidatorName)},
+		{name: "smtp hello", wantParent: string(ev.SMTPValidatorName)},
+		{name: "smtp mail", wantParent: string(ev.SMTPValidatorName)},
+		{name: "smtp randomRCPT", wantParent: string(ev.SMTPValidatorName)},
+		{name: "smtp rcpts", wantParent: string(ev.SMTPValidatorName)},
+		{name: "smtp quit", wantParent: string(ev.SMTPValidatorName)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := r.span(tt.name)
+			require.NotNil(t, span, r.names())
+			require.Equal(t, tt.wantParent, span.parentName())
+			require.True(t, span.ended)
+		})
+	}
+
+	depSpan := r.span(string(ev.DepValidatorName))
+	require.Equal(t, "domain.com", depSpan.attr(evotel.DomainKey))
+	require.Nil(t, depSpan.attr(evotel.EmailKey))
+	require.Equal(t, ev.OutcomeInvalid.String(), depSpan.attr(evotel.OutcomeKey))
+	require.Equal(t, string(ev.SMTPValidatorName), r.span(string(ev.SMTPValidatorName)).attr(evotel.ValidatorKey))
+
+	rcpts := r.span("smtp rcpts")
+	require.Equal(t, int64(550), rcpts.attr(evotel.ReplyCodeKey))
+	require.Equal(t, "mx.domain.com.", rcpts.attr(evotel.MXHostKey))
+	require.Equal(t, codes.Error, rcpts.status)
+	require.NotContains(t, rcpts.description, "john.doe")
+	require.Contains(t, rcpts.description, "***@domain.com")
+}
+
+func TestTracing_Build_RecordLocalPart(t *testing.T) {
+	r := &recorder{}
+	tracing := evotel.NewTracing(evotel.TracingDTO{TracerProvider: r, RecordLocalPart: true})
+
+	newTracedValidator(t, tracing).Validate(ev.NewInput(evmail.FromString("john.doe@domain.com")))
+
+	require.Equal(t, "john.doe@domain.com", r.span(string(ev.DepValidatorName)).attr(evotel.EmailKey))
+	require.Contains(t, r.span("smtp rcpts").description, "john.doe@domain.com")
+}
+
+func TestTracing_Build_Error(t *testing.T) {
+	tracing := evotel.NewTracing(evotel.TracingDTO{TracerProvider: &recorder{}})
+
+	_, err := tracing.Build(ev.NewDepBuilder(ev.ValidatorMap{
+		ev.SMTPValidatorName: ev.NewSMTPValidator(evsmtp.NewChecker(evsmtp.CheckerDTO{})),
+	}))
+	require.Error(t, err)
+}
+
+func TestDecorator_GetDeps(t *testing.T) {
+	validator := evotel.NewDecorator(ev.NewSMTPValidator(nil), ev.SMTPValidatorName, evotel.NewTracing(evotel.TracingDTO{}))
+
+	require.Equal(t, []ev.ValidatorName{ev.SyntaxValidatorName, ev.MXValidatorName}, validator.GetDeps())
+}
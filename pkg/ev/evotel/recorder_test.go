@@ -0,0 +1,143 @@
+package evotel_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recorder is trace.TracerProvider, which records spans in memory
+type recorder struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *recorder) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return r
+}
+
+func (r *recorder) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	span := &recordedSpan{
+		recorder: r,
+		name:     name,
+		start:    config.Timestamp(),
+		attrs:    make(map[attribute.Key]attribute.Value),
+	}
+	span.parent, _ = trace.SpanFromContext(ctx).(*recordedSpan)
+	span.SetAttributes(config.Attributes()...)
+
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// span returns the first span by name
+func (r *recorder) span(name string) *recordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, span := range r.spans {
+		if span.name == name {
+			return span
+		}
+	}
+
+	return nil
+}
+
+func (r *recorder) names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, len(r.spans))
+	for i, span := range r.spans {
+		names[i] = span.name
+	}
+
+	return names
+}
+
+type recordedSpan struct {
+	recorder    *recorder
+	mu          sync.Mutex
+	name        string
+	parent      *recordedSpan
+	start       time.Time
+	end         time.Time
+	ended       bool
+	attrs       map[attribute.Key]attribute.Value
+	status      codes.Code
+	description string
+}
+
+func (s *recordedSpan) End(options ...trace.SpanEndOption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config := trace.NewSpanEndConfig(options...)
+	s.ended = true
+	s.end = config.Timestamp()
+}
+
+func (s *recordedSpan) AddEvent(string, ...trace.EventOption) {}
+
+func (s *recordedSpan) IsRecording() bool {
+	return true
+}
+
+func (s *recordedSpan) RecordError(error, ...trace.EventOption) {}
+
+func (s *recordedSpan) SpanContext() trace.SpanContext {
+	return trace.SpanContext{}
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status, s.description = code, description
+}
+
+func (s *recordedSpan) SetName(name string) {
+	s.name = name
+}
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, attr := range kv {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordedSpan) TracerProvider() trace.TracerProvider {
+	return s.recorder
+}
+
+func (s *recordedSpan) attr(key attribute.Key) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.attrs[key]
+	if !ok {
+		return nil
+	}
+
+	return value.AsInterface()
+}
+
+func (s *recordedSpan) parentName() string {
+	if s.parent == nil {
+		return ""
+	}
+
+	return s.parent.name
+}
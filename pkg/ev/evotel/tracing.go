@@ -0,0 +1,176 @@
+package evotel
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is name of tracer of the package
+const TracerName = "github.com/prodadidb/go-email-validator/pkg/ev/evotel"
+
+// Keys of attributes of spans
+const (
+	DomainKey    = attribute.Key("email.domain")
+	EmailKey     = attribute.Key("email.address")
+	ValidatorKey = attribute.Key("ev.validator")
+	OutcomeKey   = attribute.Key("ev.outcome")
+	CacheHitKey  = attribute.Key("ev.cache.hit")
+	MXHostsKey   = attribute.Key("dns.mx.hosts")
+	MXHostKey    = attribute.Key("smtp.mx.host")
+	StageKey     = attribute.Key("smtp.stage")
+	ReplyCodeKey = attribute.Key("smtp.reply.code")
+)
+
+// Names of spans, spans of validators are named by validators
+const (
+	MXLookupSpan    = "mx lookup"
+	SMTPStagePrefix = "smtp "
+)
+
+// RedactedLocalPart replaces local parts of emails in attributes and errors
+const RedactedLocalPart = "***"
+
+// emailRegex matches emails with quoted and unicode local parts and internationalized domains,
+// the domain is captured without trailing dot of sentence
+var emailRegex = regexp.MustCompile(`(?:"[^"]*"|[^\s<>()\[\]"',;:@]+)@([^\s<>()\[\]"',;:@.]+(?:\.[^\s<>()\[\]"',;:@.]+)*)`)
+
+// Redact replaces local parts of all emails in text by RedactedLocalPart
+func Redact(text string) string {
+	return emailRegex.ReplaceAllString(text, RedactedLocalPart+"@$1")
+}
+
+// TracingDTO is DTO for NewTracing
+type TracingDTO struct {
+	// TracerProvider is otel.GetTracerProvider() if nil
+	TracerProvider trace.TracerProvider
+	// RecordLocalPart records emails with local parts, they are redacted by default
+	RecordLocalPart bool
+}
+
+// NewTracing instantiates Tracing
+func NewTracing(dto TracingDTO) *Tracing {
+	if dto.TracerProvider == nil {
+		dto.TracerProvider = otel.GetTracerProvider()
+	}
+
+	return &Tracing{
+		tracer:          dto.TracerProvider.Tracer(TracerName),
+		recordLocalPart: dto.RecordLocalPart,
+	}
+}
+
+// Tracing creates OpenTelemetry spans of validators, MX lookups and SMTP stages
+type Tracing struct {
+	tracer          trace.Tracer
+	recordLocalPart bool
+}
+
+// emailAttributes returns domain and email if local parts are recorded
+func (t *Tracing) emailAttributes(email evmail.Address) []attribute.KeyValue {
+	if email == nil {
+		return nil
+	}
+
+	attrs := []attribute.KeyValue{DomainKey.String(email.Domain())}
+	if t.recordLocalPart {
+		attrs = append(attrs, EmailKey.String(email.String()))
+	}
+
+	return attrs
+}
+
+func (t *Tracing) redact(text string) string {
+	if t.recordLocalPart {
+		return text
+	}
+
+	return Redact(text)
+}
+
+// setError sets status of span by err, text of error is redacted
+func (t *Tracing) setError(span trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, t.redact(err.Error()))
+	}
+}
+
+// LookupMX wraps lookupMX by span MXLookupSpan with domain and MX hosts
+func (t *Tracing) LookupMX(lookupMX evsmtp.FuncLookupMXContext) evsmtp.FuncLookupMXContext {
+	return func(ctx context.Context, domain string) (evsmtp.MXs, error) {
+		ctx, span := t.tracer.Start(ctx, MXLookupSpan, trace.WithAttributes(DomainKey.String(domain)))
+		defer span.End()
+
+		mxs, err := lookupMX(ctx, domain)
+		hosts := make([]string, 0, len(mxs))
+		for _, mx := range mxs {
+			if mx != nil {
+				hosts = append(hosts, mx.Host)
+			}
+		}
+		span.SetAttributes(MXHostsKey.StringSlice(hosts))
+		t.setError(span, err)
+
+		return mxs, err
+	}
+}
+
+// SMTPHooks returns evsmtp.Hooks, which create span for each stage of SMTP validation.
+// Spans are children of span in context of validation, e.g. span of Decorator of SMTP validator.
+func (t *Tracing) SMTPHooks() *evsmtp.Hooks {
+	return &evsmtp.Hooks{Stage: t.stage}
+}
+
+func (t *Tracing) stage(ctx context.Context, event evsmtp.StageEvent) {
+	attrs := []attribute.KeyValue{StageKey.String(evsmtp.StageName(event.Stage))}
+	if event.MX != "" {
+		attrs = append(attrs, MXHostKey.String(event.MX))
+	}
+
+	var protoErr *textproto.Error
+	if errors.As(event.Err, &protoErr) {
+		attrs = append(attrs, ReplyCodeKey.Int(protoErr.Code))
+	}
+
+	_, span := t.tracer.Start(ctx, SMTPStagePrefix+evsmtp.StageName(event.Stage),
+		trace.WithTimestamp(event.Start),
+		trace.WithAttributes(attrs...),
+	)
+	t.setError(span, event.Err)
+	span.End(trace.WithTimestamp(event.Start.Add(event.Duration)))
+}
+
+// CacheHook returns callback for ev.CacheDecorator.OnCache, it sets CacheHitKey to span in context
+func (t *Tracing) CacheHook() func(ctx context.Context, hit bool) {
+	return func(ctx context.Context, hit bool) {
+		trace.SpanFromContext(ctx).SetAttributes(CacheHitKey.Bool(hit))
+	}
+}
+
+// endValidation sets outcome of result, status of unknown result is error with redacted errors
+func (t *Tracing) endValidation(span trace.Span, result ev.ValidationResult) {
+	if result == nil {
+		return
+	}
+
+	span.SetAttributes(OutcomeKey.String(result.Outcome().String()))
+	if result.Outcome() != ev.OutcomeUnknown || !result.HasErrors() {
+		return
+	}
+
+	texts := make([]string, len(result.Errors()))
+	for i, err := range result.Errors() {
+		texts[i] = err.Error()
+	}
+	span.SetStatus(codes.Error, t.redact(strings.Join(texts, "; ")))
+}
@@ -0,0 +1,114 @@
+package evotel_test
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evotel"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "", want: ""},
+		{text: "domain.com", want: "domain.com"},
+		{text: "user@domain.com", want: "***@domain.com"},
+		{text: "550 5.1.1 <john.doe+tag@mail.domain.com>: user unknown", want: "550 5.1.1 <***@mail.domain.com>: user unknown"},
+		{text: "a@b.com, c@d.org", want: "***@b.com, ***@d.org"},
+		{text: "user unknown: user@domain.com.", want: "user unknown: ***@domain.com."},
+		{text: "<user@münchen.de>", want: "<***@münchen.de>"},
+		{text: "ñoño@例え.テスト", want: "***@例え.テスト"},
+		{text: `"john doe"@domain.com`, want: "***@domain.com"},
+		{text: `"a@b"@domain.com`, want: "***@domain.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			require.Equal(t, tt.want, evotel.Redact(tt.text))
+		})
+	}
+}
+
+func TestTracing_LookupMX(t *testing.T) {
+	r := &recorder{}
+	tracing := evotel.NewTracing(evotel.TracingDTO{TracerProvider: r})
+	lookupErr := &net.DNSError{Err: "no such host", Name: "domain.com", IsNotFound: true}
+
+	lookup := tracing.LookupMX(func(ctx context.Context, domain string) (evsmtp.MXs, error) {
+		if domain == "domain.com" {
+			return nil, lookupErr
+		}
+
+		return evsmtp.MXs{{Host: "mx1." + domain}, {Host: "mx2." + domain}}, nil
+	})
+
+	_, err := lookup(context.Background(), "gmail.com")
+	require.NoError(t, err)
+	_, err = lookup(context.Background(), "domain.com")
+	require.Equal(t, lookupErr, err)
+
+	require.Len(t, r.spans, 2)
+	span := r.spans[0]
+	require.Equal(t, evotel.MXLookupSpan, span.name)
+	require.True(t, span.ended)
+	require.Equal(t, "gmail.com", span.attr(evotel.DomainKey))
+	require.Equal(t, []string{"mx1.gmail.com", "mx2.gmail.com"}, span.attr(evotel.MXHostsKey))
+	require.Equal(t, codes.Unset, span.status)
+	require.Equal(t, codes.Error, r.spans[1].status)
+}
+
+func TestTracing_SMTPHooks(t *testing.T) {
+	r := &recorder{}
+	tracing := evotel.NewTracing(evotel.TracingDTO{TracerProvider: r})
+	ctx, parent := r.Start(context.Background(), "parent")
+	start := time.Now()
+
+	hooks := tracing.SMTPHooks()
+	hooks.Stage(ctx, evsmtp.StageEvent{MX: "mx.domain.com.", Stage: evsmtp.HelloStage, Start: start, Duration: time.Second})
+	hooks.Stage(ctx, evsmtp.StageEvent{
+		MX:    "mx.domain.com.",
+		Stage: evsmtp.RCPTsStage,
+		Start: start,
+		Err:   evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "<user@domain.com> unknown"}),
+	})
+	hooks.Stage(ctx, evsmtp.StageEvent{Stage: evsmtp.ConnectionStage, Start: start, Err: evsmtp.ErrConnection})
+	parent.End()
+
+	hello := r.span("smtp hello")
+	require.Equal(t, parent, hello.parent)
+	require.Equal(t, start, hello.start)
+	require.Equal(t, start.Add(time.Second), hello.end)
+	require.Equal(t, "mx.domain.com.", hello.attr(evotel.MXHostKey))
+	require.Equal(t, "hello", hello.attr(evotel.StageKey))
+	require.Nil(t, hello.attr(evotel.ReplyCodeKey))
+
+	rcpts := r.span("smtp rcpts")
+	require.Equal(t, int64(550), rcpts.attr(evotel.ReplyCodeKey))
+	require.Equal(t, codes.Error, rcpts.status)
+	require.Equal(t, `550 "<***@domain.com> unknown" happened on stage "5"`, rcpts.description)
+
+	connection := r.span("smtp connection")
+	require.Nil(t, connection.attr(evotel.MXHostKey))
+	require.Equal(t, codes.Error, connection.status)
+}
+
+func TestTracing_CacheHook(t *testing.T) {
+	r := &recorder{}
+	tracing := evotel.NewTracing(evotel.TracingDTO{TracerProvider: r})
+	ctx, _ := r.Start(context.Background(), "validator")
+
+	tracing.CacheHook()(ctx, true)
+	require.Equal(t, true, r.span("validator").attr(evotel.CacheHitKey))
+
+	// context without span
+	require.NotPanics(t, func() {
+		tracing.CacheHook()(context.Background(), false)
+	})
+}
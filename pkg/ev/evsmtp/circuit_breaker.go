@@ -0,0 +1,227 @@
+package evsmtp
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default values of CircuitBreakerDTO
+const (
+	DefaultCircuitThreshold = 5
+	DefaultCircuitCoolDown  = 30 * time.Second
+)
+
+// CircuitState is state of circuit of MX host
+type CircuitState uint8
+
+// States of circuit
+const (
+	// CircuitClosed allows connections
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects connections until cool-down is over
+	CircuitOpen
+	// CircuitHalfOpen allows one probe connection, circuit is closed after its success and is opened again after its failure
+	CircuitHalfOpen
+)
+
+var circuitStateNames = map[CircuitState]string{
+	CircuitClosed:   "closed",
+	CircuitOpen:     "open",
+	CircuitHalfOpen: "halfOpen",
+}
+
+func (c CircuitState) String() string {
+	return circuitStateNames[c]
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (c CircuitState) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// CircuitOpenErr is text for CircuitOpenError.Error
+const CircuitOpenErr = "CircuitOpenError"
+
+// CircuitOpenError is returned with CircuitOpenStage, if circuits of all MX hosts are open
+type CircuitOpenError struct {
+	Hosts []string
+}
+
+func (c *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: circuit is open for %s", CircuitOpenErr, strings.Join(c.Hosts, ", "))
+}
+
+// CircuitStatus is inspectable state of circuit of MX host
+type CircuitStatus struct {
+	Host  string       `json:"host"`
+	State CircuitState `json:"state"`
+	// Failures is number of consecutive failures
+	Failures int `json:"failures"`
+	// OpenedAt is time of the last opening of circuit
+	OpenedAt time.Time `json:"openedAt,omitempty"`
+	// RetryAt is time, after which probe connection is allowed
+	RetryAt time.Time `json:"retryAt,omitempty"`
+}
+
+// CircuitBreakerDTO is DTO for NewCircuitBreaker
+type CircuitBreakerDTO struct {
+	// Threshold is number of consecutive failures to open circuit, DefaultCircuitThreshold is used if it is 0
+	Threshold int
+	// CoolDown is time of open circuit before probe connection, DefaultCircuitCoolDown is used if it is 0
+	CoolDown time.Duration
+	// Now is time.Now by default
+	Now func() time.Time
+}
+
+// NewCircuitBreaker instantiates CircuitBreaker
+func NewCircuitBreaker(dto CircuitBreakerDTO) *CircuitBreaker {
+	if dto.Threshold <= 0 {
+		dto.Threshold = DefaultCircuitThreshold
+	}
+	if dto.CoolDown <= 0 {
+		dto.CoolDown = DefaultCircuitCoolDown
+	}
+	if dto.Now == nil {
+		dto.Now = time.Now
+	}
+
+	return &CircuitBreaker{
+		dto:      dto,
+		circuits: make(map[string]*CircuitStatus),
+	}
+}
+
+// CircuitBreaker tracks connection and response failures of MX hosts and stops connections to unhealthy hosts.
+// It is safe for concurrent use, the same CircuitBreaker can be shared by several checkers.
+type CircuitBreaker struct {
+	dto      CircuitBreakerDTO
+	mu       sync.Mutex
+	circuits map[string]*CircuitStatus
+}
+
+// Allow checks whether connection to host is allowed. Open circuit becomes half-open after cool-down
+// and allows one probe connection, other connections are rejected until the probe is reported.
+func (c *CircuitBreaker) Allow(host string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	circuit, ok := c.circuits[circuitHost(host)]
+	if !ok || circuit.State == CircuitClosed {
+		return true
+	}
+
+	now := c.dto.Now()
+	if now.Before(circuit.RetryAt) {
+		return false
+	}
+
+	// probe is allowed again, if the previous one is not reported during cool-down
+	circuit.State = CircuitHalfOpen
+	circuit.RetryAt = now.Add(c.dto.CoolDown)
+
+	return true
+}
+
+// Success closes circuit of host
+func (c *CircuitBreaker) Success(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.circuits, circuitHost(host))
+}
+
+// Failure counts failure of host, circuit is opened after Threshold consecutive failures or failure of probe
+func (c *CircuitBreaker) Failure(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	host = circuitHost(host)
+	circuit, ok := c.circuits[host]
+	if !ok {
+		circuit = &CircuitStatus{Host: host}
+		c.circuits[host] = circuit
+	}
+
+	circuit.Failures++
+	if circuit.State == CircuitHalfOpen || circuit.Failures >= c.dto.Threshold {
+		now := c.dto.Now()
+		circuit.State = CircuitOpen
+		circuit.OpenedAt = now
+		circuit.RetryAt = now.Add(c.dto.CoolDown)
+	}
+}
+
+// State returns state of circuit of host
+func (c *CircuitBreaker) State(host string) CircuitState {
+	return c.Status(host).State
+}
+
+// Status returns status of circuit of host
+func (c *CircuitBreaker) Status(host string) CircuitStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	host = circuitHost(host)
+	if circuit, ok := c.circuits[host]; ok {
+		return *circuit
+	}
+
+	return CircuitStatus{Host: host}
+}
+
+// Statuses returns statuses of hosts with failures sorted by hosts, hosts without failures are closed
+func (c *CircuitBreaker) Statuses() []CircuitStatus {
+	c.mu.Lock()
+	statuses := make([]CircuitStatus, 0, len(c.circuits))
+	for _, circuit := range c.circuits {
+		statuses = append(statuses, *circuit)
+	}
+	c.mu.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Host < statuses[j].Host
+	})
+
+	return statuses
+}
+
+// Reset closes all circuits
+func (c *CircuitBreaker) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.circuits = make(map[string]*CircuitStatus)
+}
+
+func circuitHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// isResponseFailure checks, whether errors of SMTP session are failures of server.
+// Replies of server are not failures, e.g. 550 for unknown user,
+// as well as client-side policy errors, e.g. SMTPUTF8Error for server without SMTPUTF8.
+func isResponseFailure(errs []error) bool {
+	for _, err := range errs {
+		var smtpErr Error
+		if errors.As(err, &smtpErr) && smtpErr.Stage() == RandomRCPTStage {
+			continue
+		}
+
+		var smtpUTF8Err *SMTPUTF8Error
+		if errors.As(err, &smtpUTF8Err) {
+			continue
+		}
+
+		var protoErr *textproto.Error
+		if !errors.As(err, &protoErr) {
+			return true
+		}
+	}
+
+	return false
+}
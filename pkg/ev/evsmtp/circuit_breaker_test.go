@@ -0,0 +1,195 @@
+package evsmtp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	breaker := evsmtp.NewCircuitBreaker(evsmtp.CircuitBreakerDTO{Threshold: 2, CoolDown: time.Minute, Now: clock.Now})
+	host := "MX.Domain.com."
+
+	require.True(t, breaker.Allow(host))
+	breaker.Failure(host)
+	require.Equal(t, evsmtp.CircuitClosed, breaker.State(host))
+	require.True(t, breaker.Allow(host))
+
+	breaker.Failure("mx.domain.com")
+	require.Equal(t, evsmtp.CircuitOpen, breaker.State(host))
+	require.False(t, breaker.Allow(host))
+	require.Equal(t, []evsmtp.CircuitStatus{{
+		Host:     "mx.domain.com",
+		State:    evsmtp.CircuitOpen,
+		Failures: 2,
+		OpenedAt: clock.now,
+		RetryAt:  clock.now.Add(time.Minute),
+	}}, breaker.Statuses())
+
+	// the only probe is allowed after cool-down, its failure opens circuit again
+	clock.now = clock.now.Add(time.Minute)
+	require.True(t, breaker.Allow(host))
+	require.Equal(t, evsmtp.CircuitHalfOpen, breaker.State(host))
+	require.False(t, breaker.Allow(host))
+	breaker.Failure(host)
+	require.Equal(t, evsmtp.CircuitOpen, breaker.State(host))
+	require.False(t, breaker.Allow(host))
+
+	// success of probe closes circuit
+	clock.now = clock.now.Add(time.Minute)
+	require.True(t, breaker.Allow(host))
+	breaker.Success(host)
+	require.Equal(t, evsmtp.CircuitClosed, breaker.State(host))
+	require.Empty(t, breaker.Statuses())
+
+	breaker.Failure(host)
+	breaker.Failure(host)
+	breaker.Reset()
+	require.True(t, breaker.Allow(host))
+}
+
+func TestCircuitBreaker_HalfOpen_NotReported(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	breaker := evsmtp.NewCircuitBreaker(evsmtp.CircuitBreakerDTO{Threshold: 1, CoolDown: time.Second, Now: clock.Now})
+
+	breaker.Failure("mx")
+	clock.now = clock.now.Add(time.Second)
+	require.True(t, breaker.Allow("mx"))
+	require.False(t, breaker.Allow("mx"))
+
+	clock.now = clock.now.Add(time.Second)
+	require.True(t, breaker.Allow("mx"))
+}
+
+func TestCircuitStatus_JSON(t *testing.T) {
+	data, err := json.Marshal(evsmtp.CircuitStatus{Host: "mx", State: evsmtp.CircuitHalfOpen})
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"state":"halfOpen"`)
+}
+
+func TestCircuitOpenError_Error(t *testing.T) {
+	err := evsmtp.NewError(evsmtp.CircuitOpenStage, &evsmtp.CircuitOpenError{Hosts: []string{"mx1", "mx2"}})
+
+	require.Equal(t, `CircuitOpenError: circuit is open for mx1, mx2 happened on stage "10"`, err.Error())
+	require.Equal(t, "circuitOpen", evsmtp.StageName(evsmtp.CircuitOpenStage))
+	require.True(t, evsmtp.IsTransient(err))
+}
+
+func TestChecker_ValidateContext_CircuitBreaker(t *testing.T) {
+	breaker := evsmtp.NewCircuitBreaker(evsmtp.CircuitBreakerDTO{Threshold: 2, CoolDown: time.Hour})
+	var dialed []string
+	c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		SendMailFactory: func(ctx context.Context, host string, opts evsmtp.Options) (evsmtp.SendMail, error) {
+			dialed = append(dialed, host)
+			if strings.HasPrefix(host, "mx1") {
+				return nil, errorSimple
+			}
+
+			return &mockSendMail{t: t, want: failWant(nil, false)}, nil
+		},
+		RandomEmail:    mockRandomEmail(t, randomAddress, nil),
+		Options:        &evsmtp.OptionsStruct{EmailFromOption: emailFrom},
+		CircuitBreaker: breaker,
+	})
+	mxs := evsmtp.MXs{{Host: "mx1.domain.com."}, {Host: "mx2.domain.com."}}
+
+	for i := 0; i < 3; i++ {
+		gotErrs := evsmtp.ValidateContext(context.Background(), c, mxs, evsmtp.NewInput(emailTo, nil))
+		require.Empty(t, gotErrs)
+	}
+	// mx1 is skipped after 2 failures
+	require.Equal(t, []string{
+		"mx1.domain.com.:25", "mx2.domain.com.:25",
+		"mx1.domain.com.:25", "mx2.domain.com.:25",
+		"mx2.domain.com.:25",
+	}, dialed)
+	require.Equal(t, evsmtp.CircuitOpen, breaker.State("mx1.domain.com"))
+	require.Equal(t, evsmtp.CircuitClosed, breaker.State("mx2.domain.com"))
+
+	gotErrs := evsmtp.ValidateContext(context.Background(), c, mxs[:1], evsmtp.NewInput(emailTo, nil))
+	require.Equal(t, utils.Errs(evsmtp.NewError(evsmtp.CircuitOpenStage, &evsmtp.CircuitOpenError{Hosts: []string{"mx1.domain.com"}})), gotErrs)
+}
+
+func TestChecker_ValidateContext_CircuitBreaker_Response(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantState evsmtp.CircuitState
+	}{
+		{
+			name:      "network error",
+			err:       &net.OpError{Op: "read", Err: errors.New("connection reset")},
+			wantState: evsmtp.CircuitOpen,
+		},
+		{
+			name:      "reply of server",
+			err:       &textproto.Error{Code: 550, Msg: "rejected"},
+			wantState: evsmtp.CircuitClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := evsmtp.NewCircuitBreaker(evsmtp.CircuitBreakerDTO{Threshold: 1})
+			c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+				SendMailFactory: func(ctx context.Context, host string, opts evsmtp.Options) (evsmtp.SendMail, error) {
+					return &mockSendMail{t: t, want: failWant(&sendMailWant{
+						stage:   smHello,
+						message: smHelloLocalhost,
+						ret:     tt.err,
+					}, true)}, nil
+				},
+				RandomEmail:    mockRandomEmail(t, randomAddress, nil),
+				Options:        &evsmtp.OptionsStruct{EmailFromOption: emailFrom},
+				CircuitBreaker: breaker,
+			})
+
+			gotErrs := evsmtp.ValidateContext(context.Background(), c, mxs, evsmtp.NewInput(emailTo, nil))
+			require.Equal(t, utils.Errs(evsmtp.NewError(evsmtp.HelloStage, tt.err)), gotErrs)
+			require.Equal(t, tt.wantState, breaker.State(localhost))
+		})
+	}
+}
+
+func TestChecker_ValidateContext_CircuitBreaker_SMTPUTF8(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := NewMockSMTPClient(ctrl)
+	client.EXPECT().Extension(evsmtp.SMTPUTF8Extension).Return(false, "").Times(1)
+	emailUTF8 := evmail.FromString("josé@bücher.de")
+
+	breaker := evsmtp.NewCircuitBreaker(evsmtp.CircuitBreakerDTO{Threshold: 1})
+	c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		SendMailFactory: func(ctx context.Context, host string, opts evsmtp.Options) (evsmtp.SendMail, error) {
+			return &mockSendMail{t: t, want: failWant(&sendMailWant{stage: smMail, message: smClient, ret: client}, true)}, nil
+		},
+		RandomEmail:    mockRandomEmail(t, randomAddress, nil),
+		Options:        &evsmtp.OptionsStruct{EmailFromOption: emailFrom},
+		CircuitBreaker: breaker,
+	})
+
+	gotErrs := evsmtp.ValidateContext(context.Background(), c, mxs, evsmtp.NewInput(emailUTF8, nil))
+	require.Equal(t, utils.Errs(evsmtp.NewError(evsmtp.MailStage, &evsmtp.SMTPUTF8Error{Mailbox: emailUTF8.String()})), gotErrs)
+	// server without SMTPUTF8 is not failed
+	require.Equal(t, evsmtp.CircuitClosed, breaker.State(localhost))
+}
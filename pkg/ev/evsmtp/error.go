@@ -1,14 +1,17 @@
 package evsmtp
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/textproto"
 	"net/url"
 	"reflect"
+	"strings"
 
 	"github.com/vmihailenco/msgpack"
 )
@@ -16,8 +19,19 @@ import (
 // Used 0 because of https://github.com/msgpack/msgpack/blob/master/spec.md#extension-types
 var registerExtID int8 = 0
 
+// Fixed ext ids of types, which were added after types of package ev.
+// Ids are stored in caches, so they are not changed, ExtID skips them.
+const (
+	CircuitOpenErrorExtID int8 = 26
+	SMTPUTF8ErrorExtID    int8 = 27
+)
+
 // ExtID returns register extent id, used for msgpack.RegisterExt
 func ExtID() int8 {
+	for registerExtID == CircuitOpenErrorExtID || registerExtID == SMTPUTF8ErrorExtID {
+		registerExtID++
+	}
+
 	registerExtID++
 	return registerExtID - 1
 }
@@ -55,6 +69,9 @@ func init() {
 	msgpack.RegisterExt(ExtID(), new(x509.InsecureAlgorithmError))
 	msgpack.RegisterExt(ExtID(), new(x509.ConstraintViolationError))
 
+	msgpack.RegisterExt(CircuitOpenErrorExtID, new(CircuitOpenError))
+	msgpack.RegisterExt(SMTPUTF8ErrorExtID, new(SMTPUTF8Error))
+
 	msgpack.Register(errors.New(""), func(e *msgpack.Encoder, v reflect.Value) error {
 		if v.IsNil() {
 			return e.EncodeNil()
@@ -63,13 +80,46 @@ func init() {
 	}, nil)
 }
 
-// Error is interface of Checker errors
+// Error is interface of Checker errors.
+// ASMTPError implements ev.CodedError, use type assertion to get its code, category and details.
 type Error interface {
 	error
 	Stage() SendMailStage
 	Unwrap() error
 }
 
+// Categories of Error, they are equal to categories of ev.CodedError
+const (
+	MailboxCategory   = "mailbox"
+	PolicyCategory    = "policy"
+	TransientCategory = "transient"
+)
+
+// UnknownStageCode is code of errors on unknown stages
+const UnknownStageCode = "smtp_unknown"
+
+var stageCodes = map[SendMailStage]string{
+	ClientStage:      "smtp_client",
+	HelloStage:       "smtp_hello",
+	AuthStage:        "smtp_auth",
+	MailStage:        "smtp_mail",
+	RCPTsStage:       "smtp_rcpt",
+	QuitStage:        "smtp_quit",
+	CloseStage:       "smtp_close",
+	RandomRCPTStage:  "smtp_random_rcpt",
+	ConnectionStage:  "smtp_connection",
+	CircuitOpenStage: "smtp_circuit_open",
+}
+
+// StageCode returns stable code of errors on stage
+func StageCode(stage SendMailStage) string {
+	if code, ok := stageCodes[stage]; ok {
+		return code
+	}
+
+	return UnknownStageCode
+}
+
 // AliasError is alias to fix msgpack
 type AliasError error
 
@@ -92,6 +142,61 @@ func (a *ASMTPError) Error() string {
 	return fmt.Sprintf("%v happened on stage \"%v\"", errors.Unwrap(a).Error(), a.Stage())
 }
 
+// codedError is wrapped error with own code and category, e.g. SMTPUTF8Error
+type codedError interface {
+	Code() string
+	Category() string
+}
+
+// Code returns code of wrapped error if it has own code, otherwise code of stage by StageCode
+func (a *ASMTPError) Code() string {
+	var coded codedError
+	if errors.As(a.err, &coded) {
+		return coded.Code()
+	}
+
+	return StageCode(a.stage)
+}
+
+// Category returns category of wrapped error if it has own category, TransientCategory for transient errors,
+// MailboxCategory for rejected recipients, otherwise PolicyCategory
+func (a *ASMTPError) Category() string {
+	var coded codedError
+	switch {
+	case errors.As(a.err, &coded):
+		return coded.Category()
+	case IsTransient(a):
+		return TransientCategory
+	case a.stage == RCPTsStage || a.stage == RandomRCPTStage:
+		return MailboxCategory
+	}
+
+	return PolicyCategory
+}
+
+// Details returns stage, reply code and message of server, hosts of open circuits or mailbox without SMTPUTF8
+func (a *ASMTPError) Details() map[string]interface{} {
+	details := map[string]interface{}{"stage": StageName(a.stage)}
+
+	var protoErr *textproto.Error
+	if errors.As(a.err, &protoErr) {
+		details["replyCode"] = protoErr.Code
+		details["message"] = protoErr.Msg
+	}
+
+	var circuitErr *CircuitOpenError
+	if errors.As(a.err, &circuitErr) {
+		details["hosts"] = circuitErr.Hosts
+	}
+
+	var utf8Err *SMTPUTF8Error
+	if errors.As(a.err, &utf8Err) {
+		details["mailbox"] = utf8Err.Mailbox
+	}
+
+	return details
+}
+
 // EncodeMsgpack implements encoder for msgpack
 func (a *ASMTPError) EncodeMsgpack(enc *msgpack.Encoder) error {
 	return enc.EncodeMulti(a.stage, a.err)
@@ -102,6 +207,97 @@ func (a *ASMTPError) DecodeMsgpack(dec *msgpack.Decoder) error {
 	return dec.DecodeMulti(&a.stage, &a.err)
 }
 
+// errorJSON is JSON representation of ASMTPError
+type errorJSON struct {
+	Stage string `json:"stage"`
+	// Type is type of wrapped error, e.g. textproto.Error
+	Type string `json:"type,omitempty"`
+	// Message is message of wrapped error, it is message of server for textproto.Error
+	Message   string   `json:"message"`
+	ReplyCode int      `json:"replyCode,omitempty"`
+	Hosts     []string `json:"hosts,omitempty"`
+	Mailbox   string   `json:"mailbox,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, it keeps stage, type, reply code and message of wrapped error
+func (a *ASMTPError) MarshalJSON() ([]byte, error) {
+	data := errorJSON{Stage: StageName(a.stage)}
+	if a.err != nil {
+		data.Type = strings.TrimPrefix(fmt.Sprintf("%T", a.err), "*")
+		data.Message = a.err.Error()
+	}
+
+	var protoErr *textproto.Error
+	var circuitErr *CircuitOpenError
+	var utf8Err *SMTPUTF8Error
+	switch {
+	case errors.As(a.err, &protoErr):
+		data.ReplyCode = protoErr.Code
+		data.Message = protoErr.Msg
+	case errors.As(a.err, &circuitErr):
+		data.Hosts = circuitErr.Hosts
+	case errors.As(a.err, &utf8Err):
+		data.Mailbox = utf8Err.Mailbox
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Replies of server, open circuits, mailboxes without SMTPUTF8
+// and errors of context are restored, other wrapped errors are restored only by messages.
+func (a *ASMTPError) UnmarshalJSON(b []byte) error {
+	var data errorJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	a.stage, _ = StageByName(data.Stage)
+	switch {
+	case data.ReplyCode != 0:
+		a.err = &textproto.Error{Code: data.ReplyCode, Msg: data.Message}
+	case data.Hosts != nil:
+		a.err = &CircuitOpenError{Hosts: data.Hosts}
+	case data.Mailbox != "":
+		a.err = &SMTPUTF8Error{Mailbox: data.Mailbox}
+	case data.Message == context.Canceled.Error():
+		a.err = context.Canceled
+	case data.Message == context.DeadlineExceeded.Error():
+		a.err = context.DeadlineExceeded
+	case data.Type != "":
+		a.err = errors.New(data.Message)
+	default:
+		a.err = nil
+	}
+
+	return nil
+}
+
+// SMTPUTF8Code is code of SMTPUTF8Error
+const SMTPUTF8Code = "smtp_utf8_unsupported"
+
+// SMTPUTF8Err is text for SMTPUTF8Error.Error
+const SMTPUTF8Err = "SMTPUTF8Error"
+
+// SMTPUTF8Error is returned with MailStage, if mailbox has non-ASCII local part,
+// but server does not support SMTPUTF8 extension (RFC 6531)
+type SMTPUTF8Error struct {
+	Mailbox string
+}
+
+func (s *SMTPUTF8Error) Error() string {
+	return fmt.Sprintf("%s: server does not support SMTPUTF8 for %s", SMTPUTF8Err, s.Mailbox)
+}
+
+// Code returns SMTPUTF8Code
+func (s *SMTPUTF8Error) Code() string {
+	return SMTPUTF8Code
+}
+
+// Category returns MailboxCategory, because mailbox can not receive emails from the server
+func (s *SMTPUTF8Error) Category() string {
+	return MailboxCategory
+}
+
 // NewError is constructor for DefaultError
 func NewError(stage SendMailStage, err error) Error {
 	return &DefaultError{ASMTPError{stage, err}}
@@ -132,3 +328,38 @@ func ErrorsToEVSMTPErrors(errs []error) (Errs []AliasError) {
 
 	return
 }
+
+// IsTransient checks, whether err is temporary and validation can succeed later.
+// Canceled and timed out validations, failed connections, open circuits, temporary DNS failures
+// and 4xx SMTP replies (e.g. greylisting) are transient.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var smtpErr Error
+	if errors.As(err, &smtpErr) && (smtpErr.Stage() == ConnectionStage || smtpErr.Stage() == CircuitOpenStage) {
+		return true
+	}
+
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code >= 400 && protoErr.Code < 500
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+
+	return false
+}
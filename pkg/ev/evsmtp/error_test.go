@@ -0,0 +1,203 @@
+package evsmtp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/textproto"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "simple", err: errorSimple, want: false},
+		{name: "canceled", err: context.Canceled, want: true},
+		{name: "deadline", err: evsmtp.NewError(evsmtp.HelloStage, context.DeadlineExceeded), want: true},
+		{name: "connection", err: evsmtp.ErrConnection, want: true},
+		{name: "4xx", err: evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 451}), want: true},
+		{name: "5xx", err: evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550}), want: false},
+		{name: "dns temporary", err: &net.DNSError{IsTemporary: true}, want: true},
+		{name: "dns timeout", err: &net.DNSError{IsTimeout: true}, want: true},
+		{name: "dns not found", err: &net.DNSError{IsNotFound: true}, want: false},
+		{name: "net timeout", err: &net.OpError{Op: "dial", Err: &net.DNSError{IsTimeout: true}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, evsmtp.IsTransient(tt.err))
+		})
+	}
+}
+
+// codedError is ev.CodedError, it is declared here to avoid import of ev
+type codedError interface {
+	Code() string
+	Category() string
+	Details() map[string]interface{}
+}
+
+func TestASMTPError_Coded(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          evsmtp.Error
+		wantCode     string
+		wantCategory string
+		wantDetails  map[string]interface{}
+	}{
+		{
+			name:         "rejected recipient",
+			err:          evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "unknown"}),
+			wantCode:     "smtp_rcpt",
+			wantCategory: evsmtp.MailboxCategory,
+			wantDetails:  map[string]interface{}{"stage": "rcpts", "replyCode": 550, "message": "unknown"},
+		},
+		{
+			name:         "greylisting",
+			err:          evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 451, Msg: "later"}),
+			wantCode:     "smtp_rcpt",
+			wantCategory: evsmtp.TransientCategory,
+			wantDetails:  map[string]interface{}{"stage": "rcpts", "replyCode": 451, "message": "later"},
+		},
+		{
+			name:         "rejected sender",
+			err:          evsmtp.NewError(evsmtp.MailStage, &textproto.Error{Code: 554, Msg: "blocked"}),
+			wantCode:     "smtp_mail",
+			wantCategory: evsmtp.PolicyCategory,
+			wantDetails:  map[string]interface{}{"stage": "mail", "replyCode": 554, "message": "blocked"},
+		},
+		{
+			name:         "connection",
+			err:          evsmtp.ErrConnection,
+			wantCode:     "smtp_connection",
+			wantCategory: evsmtp.TransientCategory,
+			wantDetails:  map[string]interface{}{"stage": "connection"},
+		},
+		{
+			name:         "circuit open",
+			err:          evsmtp.NewError(evsmtp.CircuitOpenStage, &evsmtp.CircuitOpenError{Hosts: []string{"mx"}}),
+			wantCode:     "smtp_circuit_open",
+			wantCategory: evsmtp.TransientCategory,
+			wantDetails:  map[string]interface{}{"stage": "circuitOpen", "hosts": []string{"mx"}},
+		},
+		{
+			name:         "smtputf8",
+			err:          evsmtp.NewError(evsmtp.MailStage, &evsmtp.SMTPUTF8Error{Mailbox: "josé@example.com"}),
+			wantCode:     evsmtp.SMTPUTF8Code,
+			wantCategory: evsmtp.MailboxCategory,
+			wantDetails:  map[string]interface{}{"stage": "mail", "mailbox": "josé@example.com"},
+		},
+		{
+			name:         "unknown stage",
+			err:          evsmtp.NewError(0, errorSimple),
+			wantCode:     evsmtp.UnknownStageCode,
+			wantCategory: evsmtp.PolicyCategory,
+			wantDetails:  map[string]interface{}{"stage": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coded, ok := tt.err.(codedError)
+			require.True(t, ok)
+			require.Equal(t, tt.wantCode, coded.Code())
+			require.Equal(t, tt.wantCategory, coded.Category())
+			require.Equal(t, tt.wantDetails, coded.Details())
+		})
+	}
+}
+
+func TestASMTPError_JSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      evsmtp.Error
+		wantJSON string
+		want     evsmtp.Error
+	}{
+		{
+			name:     "reply",
+			err:      evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "unknown"}),
+			wantJSON: `{"stage":"rcpts","type":"textproto.Error","message":"unknown","replyCode":550}`,
+		},
+		{
+			name:     "circuit open",
+			err:      evsmtp.NewError(evsmtp.CircuitOpenStage, &evsmtp.CircuitOpenError{Hosts: []string{"mx"}}),
+			wantJSON: `{"stage":"circuitOpen","type":"evsmtp.CircuitOpenError","message":"CircuitOpenError: circuit is open for mx","hosts":["mx"]}`,
+		},
+		{
+			name:     "smtputf8",
+			err:      evsmtp.NewError(evsmtp.MailStage, &evsmtp.SMTPUTF8Error{Mailbox: "josé@example.com"}),
+			wantJSON: `{"stage":"mail","type":"evsmtp.SMTPUTF8Error","message":"SMTPUTF8Error: server does not support SMTPUTF8 for josé@example.com","mailbox":"josé@example.com"}`,
+		},
+		{
+			name:     "canceled",
+			err:      evsmtp.NewError(evsmtp.HelloStage, context.Canceled),
+			wantJSON: `{"stage":"hello","type":"errors.errorString","message":"context canceled"}`,
+		},
+		{
+			name:     "connection",
+			err:      evsmtp.ErrConnection,
+			wantJSON: `{"stage":"connection","type":"errors.errorString","message":"` + evsmtp.ErrConnectionMsg + `"}`,
+		},
+		{
+			name:     "net error",
+			err:      evsmtp.NewError(evsmtp.MailStage, &net.OpError{Op: "read", Net: "tcp", Err: errorSimple}),
+			wantJSON: `{"stage":"mail","type":"net.OpError","message":"read tcp: errorSimple"}`,
+			want:     evsmtp.NewError(evsmtp.MailStage, errors.New("read tcp: errorSimple")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.err)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.wantJSON, string(data))
+
+			got := &evsmtp.DefaultError{}
+			require.NoError(t, json.Unmarshal(data, got))
+
+			want := tt.want
+			if want == nil {
+				want = tt.err
+			}
+			require.Equal(t, want, got)
+			require.Equal(t, evsmtp.IsTransient(want), evsmtp.IsTransient(got))
+		})
+	}
+}
+
+func TestASMTPError_UnmarshalJSON_Error(t *testing.T) {
+	require.Error(t, json.Unmarshal([]byte(`[]`), &evsmtp.DefaultError{}))
+}
+
+func TestASMTPError_Msgpack(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "circuit open",
+			err:  evsmtp.NewError(evsmtp.CircuitOpenStage, &evsmtp.CircuitOpenError{Hosts: []string{"mx.domain.com"}}),
+		},
+		{
+			name: "smtputf8",
+			err:  evsmtp.NewError(evsmtp.MailStage, &evsmtp.SMTPUTF8Error{Mailbox: "ñoño@domain.com"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := msgpack.Marshal(tt.err)
+			require.NoError(t, err)
+
+			got := new(evsmtp.DefaultError)
+			require.NoError(t, msgpack.Unmarshal(data, got))
+			require.Equal(t, tt.err, got)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package evsmtp
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// StageEvent is finished stage of CheckerStruct
+type StageEvent struct {
+	// MX is host of connected MX record, it is empty if connection was not created
+	MX       string
+	Stage    SendMailStage
+	Start    time.Time
+	Duration time.Duration
+	// Err is error of the stage, e.g. reply of server
+	Err error
+}
+
+// Hooks are callbacks of checkers, e.g. for metrics.
+// Nil callbacks are not called, callbacks should be safe for concurrent use.
+type Hooks struct {
+	// Stage is called for each stage after validation by CheckerStruct, ctx is context of the validation
+	Stage func(ctx context.Context, event StageEvent)
+	// Cache is called by CheckerCacheRandomRCPTStruct with result of cache lookup
+	Cache func(hit bool)
+}
+
+// JoinHooks returns Hooks, which call callbacks of all hooks in order, nil hooks are skipped
+func JoinHooks(hooks ...*Hooks) *Hooks {
+	var stages []func(ctx context.Context, event StageEvent)
+	var caches []func(hit bool)
+	for _, h := range hooks {
+		if h == nil {
+			continue
+		}
+		if h.Stage != nil {
+			stages = append(stages, h.Stage)
+		}
+		if h.Cache != nil {
+			caches = append(caches, h.Cache)
+		}
+	}
+
+	joined := &Hooks{}
+	if len(stages) > 0 {
+		joined.Stage = func(ctx context.Context, event StageEvent) {
+			for _, stage := range stages {
+				stage(ctx, event)
+			}
+		}
+	}
+	if len(caches) > 0 {
+		joined.Cache = func(hit bool) {
+			for _, cache := range caches {
+				cache(hit)
+			}
+		}
+	}
+
+	return joined
+}
+
+func (h *Hooks) stageEnabled() bool {
+	return h != nil && h.Stage != nil
+}
+
+// stages calls Hooks.Stage for timings, errors of stages without timings are reported with zero duration
+func (h *Hooks) stages(ctx context.Context, mx string, timings []StageTiming, errs []error) {
+	if !h.stageEnabled() {
+		return
+	}
+
+	stageErrs := make(map[SendMailStage]error, len(errs))
+	var stages []SendMailStage
+	for _, err := range errs {
+		var smtpErr Error
+		if !errors.As(err, &smtpErr) {
+			continue
+		}
+		if _, ok := stageErrs[smtpErr.Stage()]; !ok {
+			stageErrs[smtpErr.Stage()] = err
+			stages = append(stages, smtpErr.Stage())
+		}
+	}
+
+	for _, timing := range timings {
+		h.Stage(ctx, StageEvent{MX: mx, Stage: timing.Stage, Start: timing.Start, Duration: timing.Duration, Err: stageErrs[timing.Stage]})
+		delete(stageErrs, timing.Stage)
+	}
+
+	for _, stage := range stages {
+		if err, ok := stageErrs[stage]; ok {
+			h.Stage(ctx, StageEvent{MX: mx, Stage: stage, Start: time.Now(), Err: err})
+		}
+	}
+}
+
+func (h *Hooks) cache(hit bool) {
+	if h != nil && h.Cache != nil {
+		h.Cache(hit)
+	}
+}
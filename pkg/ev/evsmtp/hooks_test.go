@@ -0,0 +1,114 @@
+package evsmtp_test
+
+import (
+	"context"
+	"net/textproto"
+	"sync"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/stretchr/testify/require"
+)
+
+type stageEvents struct {
+	mu     sync.Mutex
+	events []evsmtp.StageEvent
+}
+
+func (s *stageEvents) add(_ context.Context, event evsmtp.StageEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+}
+
+func (s *stageEvents) stages() map[evsmtp.SendMailStage]error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stages := make(map[evsmtp.SendMailStage]error, len(s.events))
+	for _, event := range s.events {
+		stages[event.Stage] = event.Err
+	}
+
+	return stages
+}
+
+func TestChecker_ValidateContext_Hooks(t *testing.T) {
+	rcptErr := &textproto.Error{Code: 550, Msg: "user unknown"}
+	events := &stageEvents{}
+	c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		SendMailFactory: func(ctx context.Context, host string, opts evsmtp.Options) (evsmtp.SendMail, error) {
+			return &mockSendMail{t: t, want: append(failWant(&sendMailWant{
+				stage:   smRCPTs,
+				message: smRCPTs + randomAddress.String(),
+				ret:     errorSimple,
+			}, false), sendMailWant{message: smRCPTs + emailTo.String(), ret: rcptErr}, quitStageWant)}, nil
+		},
+		RandomEmail: mockRandomEmail(t, randomAddress, nil),
+		Options:     &evsmtp.OptionsStruct{EmailFromOption: emailFrom},
+		Hooks:       &evsmtp.Hooks{Stage: events.add},
+	})
+
+	gotErrs := evsmtp.ValidateContext(context.Background(), c, mxs, evsmtp.NewInput(emailTo, nil))
+	require.Len(t, gotErrs, 2)
+
+	require.Equal(t, map[evsmtp.SendMailStage]error{
+		evsmtp.ConnectionStage: nil,
+		evsmtp.HelloStage:      nil,
+		evsmtp.AuthStage:       nil,
+		evsmtp.MailStage:       nil,
+		evsmtp.RandomRCPTStage: gotErrs[0],
+		evsmtp.RCPTsStage:      gotErrs[1],
+		evsmtp.QuitStage:       nil,
+	}, events.stages())
+	for _, event := range events.events {
+		require.Equal(t, localhost, event.MX)
+	}
+}
+
+func TestChecker_ValidateContext_Hooks_WithoutConnection(t *testing.T) {
+	events := &stageEvents{}
+	c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		SendMailFactory: func(ctx context.Context, host string, opts evsmtp.Options) (evsmtp.SendMail, error) {
+			return nil, errorSimple
+		},
+		Hooks: &evsmtp.Hooks{Stage: events.add},
+	})
+
+	gotErrs := evsmtp.ValidateContext(context.Background(), c, mxs, evsmtp.NewInput(emailTo, nil))
+
+	require.Len(t, events.events, 1)
+	require.Equal(t, evsmtp.StageEvent{
+		Stage:    evsmtp.ConnectionStage,
+		Start:    events.events[0].Start,
+		Duration: events.events[0].Duration,
+		Err:      gotErrs[0],
+	}, events.events[0])
+	require.False(t, events.events[0].Start.IsZero())
+}
+
+func TestJoinHooks(t *testing.T) {
+	var calls []string
+	hooks := evsmtp.JoinHooks(
+		&evsmtp.Hooks{
+			Stage: func(context.Context, evsmtp.StageEvent) {
+				calls = append(calls, "stage 1")
+			},
+		},
+		nil,
+		&evsmtp.Hooks{
+			Stage: func(context.Context, evsmtp.StageEvent) {
+				calls = append(calls, "stage 2")
+			},
+			Cache: func(bool) {
+				calls = append(calls, "cache 2")
+			},
+		},
+	)
+
+	hooks.Stage(context.Background(), evsmtp.StageEvent{})
+	hooks.Cache(true)
+	require.Equal(t, []string{"stage 1", "stage 2", "cache 2"}, calls)
+	require.Equal(t, &evsmtp.Hooks{}, evsmtp.JoinHooks(nil))
+}
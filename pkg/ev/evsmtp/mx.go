@@ -1,13 +1,29 @@
 package evsmtp
 
 import (
+	"context"
 	"net"
 )
 
 // FuncLookupMX returns MXs
 type FuncLookupMX func(domain string) (MXs, error)
 
+// FuncLookupMXContext returns MXs, the lookup is canceled with ctx
+type FuncLookupMXContext func(ctx context.Context, domain string) (MXs, error)
+
 // LookupMX is default realization for looking net.MX
 func LookupMX(domain string) (MXs, error) {
 	return net.LookupMX(domain)
 }
+
+// LookupMXContext is default realization for looking net.MX with context.Context
+func LookupMXContext(ctx context.Context, domain string) (MXs, error) {
+	return net.DefaultResolver.LookupMX(ctx, domain)
+}
+
+// LookupMXWithContext converts FuncLookupMX to FuncLookupMXContext, ctx is ignored
+func LookupMXWithContext(lookupMX FuncLookupMX) FuncLookupMXContext {
+	return func(_ context.Context, domain string) (MXs, error) {
+		return lookupMX(domain)
+	}
+}
@@ -1,11 +1,13 @@
 package evsmtp_test
 
 import (
+	"context"
 	"net"
 	"reflect"
 	"testing"
 
 	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLookupMX(t *testing.T) {
@@ -71,3 +73,25 @@ func TestLookupMX(t *testing.T) {
 		})
 	}
 }
+
+func TestLookupMXContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	got, err := evsmtp.LookupMXContext(ctx, "gmail.com")
+	require.Error(t, err)
+	require.Nil(t, got)
+}
+
+func TestLookupMXWithContext(t *testing.T) {
+	want := evsmtp.MXs{&net.MX{Host: "mx.example.org."}}
+	lookupMX := evsmtp.LookupMXWithContext(func(domain string) (evsmtp.MXs, error) {
+		require.Equal(t, "example.org", domain)
+
+		return want, nil
+	})
+
+	got, err := lookupMX(context.Background(), "example.org")
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
@@ -17,7 +17,9 @@ type SendMailStage uint8
 // SafeSendMailStage is thread safe SendMailStage
 type SafeSendMailStage struct {
 	SendMailStage
-	mu sync.RWMutex
+	// Trace records transitions between stages, it can be nil
+	Trace *StagesTrace
+	mu    sync.RWMutex
 }
 
 // Set sets stage
@@ -25,6 +27,7 @@ func (s *SafeSendMailStage) Set(val SendMailStage) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.SendMailStage = val
+	s.Trace.Start(val)
 }
 
 // Get returns stage
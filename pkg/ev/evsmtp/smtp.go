@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/modern-go/reflect2"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evcache"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
 	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
 	"github.com/prodadidb/go-email-validator/pkg/log"
@@ -35,6 +36,8 @@ type MXs = []*net.MX
 const (
 	RandomRCPTStage = CloseStage + 1
 	ConnectionStage = RandomRCPTStage + 1
+	// CircuitOpenStage is stage of CircuitOpenError, connections are not created
+	CircuitOpenStage = ConnectionStage + 1
 )
 
 var (
@@ -49,6 +52,22 @@ type Checker interface {
 	Validate(mxs MXs, input Input) []error
 }
 
+// ContextChecker is Checker, which supports cancellation and deadlines by context.Context
+type ContextChecker interface {
+	Checker
+	ValidateContext(ctx context.Context, mxs MXs, input Input) []error
+}
+
+// ValidateContext validates by ContextChecker.ValidateContext if checker implements it,
+// otherwise Checker.Validate is used and ctx is ignored.
+func ValidateContext(ctx context.Context, checker Checker, mxs MXs, input Input) []error {
+	if ctxChecker, ok := checker.(ContextChecker); ok {
+		return ctxChecker.ValidateContext(ctx, mxs, input)
+	}
+
+	return checker.Validate(mxs, input)
+}
+
 // CheckerWithRandomRCPT is used for caching of RandomRCPT
 type CheckerWithRandomRCPT interface {
 	Checker
@@ -65,6 +84,25 @@ type RandomRCPT interface {
 	Get() RandomRCPTFunc
 }
 
+// RandomRCPTContextFunc is RandomRCPTFunc, which supports cancellation and deadlines by context.Context
+type RandomRCPTContextFunc func(ctx context.Context, sm SendMail, email evmail.Address) (errs []error)
+
+// ContextRandomRCPT is RandomRCPT, which supports cancellation and deadlines by context.Context
+type ContextRandomRCPT interface {
+	RandomRCPT
+	CallContext(ctx context.Context, sm SendMail, email evmail.Address) []error
+	SetContext(fn RandomRCPTContextFunc)
+}
+
+// callRandomRCPT calls ContextRandomRCPT.CallContext if randomRCPT implements it, otherwise ctx is ignored
+func callRandomRCPT(ctx context.Context, randomRCPT RandomRCPT, sm SendMail, email evmail.Address) []error {
+	if ctxRandomRCPT, ok := randomRCPT.(ContextRandomRCPT); ok {
+		return ctxRandomRCPT.CallContext(ctx, sm, email)
+	}
+
+	return randomRCPT.Call(sm, email)
+}
+
 // RandomEmail is function type to generate random email for checking of Catching All emails by RCPTs
 type RandomEmail func(domain string) (evmail.Address, error)
 
@@ -81,6 +119,10 @@ type CheckerDTO struct {
 	SendMailFactory SendMailDialerFactory
 	RandomEmail     RandomEmail
 	Options         Options
+	// CircuitBreaker stops connections to unhealthy MX hosts, it is disabled if nil
+	CircuitBreaker *CircuitBreaker
+	// Hooks receive stages of validations, e.g. for metrics
+	Hooks *Hooks
 }
 
 // NewChecker instantiates Checker
@@ -111,6 +153,8 @@ func NewChecker(dto CheckerDTO) Checker {
 		Auth:            nil,
 		RandomEmail:     dto.RandomEmail,
 		Options:         NewOptions(opts),
+		CircuitBreaker:  dto.CircuitBreaker,
+		Hooks:           dto.Hooks,
 	}
 	c.RandomRCPT = &ARandomRCPT{fn: c.randomRCPT}
 
@@ -124,6 +168,8 @@ type CheckerStruct struct {
 	Auth            smtp.Auth
 	RandomEmail     RandomEmail
 	Options         Options
+	CircuitBreaker  *CircuitBreaker
+	Hooks           *Hooks
 }
 
 type sendMailRWMutex struct {
@@ -146,11 +192,29 @@ func (s *sendMailRWMutex) Get() SendMail {
 	return s.sendMail
 }
 
-func (c CheckerStruct) Validate(mxs MXs, input Input) (errs []error) {
+func (c CheckerStruct) Validate(mxs MXs, input Input) []error {
+	return c.ValidateContext(context.Background(), mxs, input)
+}
+
+// ValidateContext validates input, connection and communication with smtp server are interrupted after ctx is done
+func (c CheckerStruct) ValidateContext(parentCtx context.Context, mxs MXs, input Input) (errs []error) {
 	var smMutex = &sendMailRWMutex{}
 	var err error
 	errs = make([]error, 0)
 	var host string
+	var mxHost string
+
+	trace := StagesTraceFromContext(parentCtx)
+	if trace == nil && c.Hooks.stageEnabled() {
+		trace = NewStagesTrace()
+	}
+	if c.Hooks.stageEnabled() {
+		defer func() {
+			c.Hooks.stages(parentCtx, mxHost, trace.Timings(), errs)
+		}()
+	}
+	trace.Start(ConnectionStage)
+	defer trace.Finish()
 
 	email := input.Email()
 	opts := NewOptions(OptionsDTO{
@@ -162,49 +226,78 @@ func (c CheckerStruct) Validate(mxs MXs, input Input) (errs []error) {
 		Port:        utils.DefaultInt(input.Port(), c.Options.Port()),
 	})
 
+	var openHosts []string
 	for _, mx := range mxs {
+		if c.CircuitBreaker != nil && !c.CircuitBreaker.Allow(mx.Host) {
+			openHosts = append(openHosts, circuitHost(mx.Host))
+			continue
+		}
 		host = fmt.Sprintf("%v:%v", mx.Host, opts.Port())
 
-		func() {
+		connected := func() bool {
 			var cancel context.CancelFunc
-			var ctx context.Context
-			ctx = context.Background()
+			var ctx = parentCtx
 			if opts.TimeoutConnection() > 0 {
 				// TODO think about logging of timeout connection error
 				ctx, cancel = context.WithTimeout(ctx, opts.TimeoutConnection())
 				defer cancel()
 			}
 
-			done := make(chan struct{}, 1)
+			dialed := make(chan SendMail, 1)
 			go func() {
-				defer close(done)
-				var errSM error
-
 				sendMail, errSM := c.SendMailFactory(ctx, host, input)
-				if errSM == nil {
-					smMutex.Set(sendMail)
+				if errSM != nil {
+					sendMail = nil
 				}
+				dialed <- sendMail
 			}()
 
 			select {
 			case <-ctx.Done():
-				return
-			case <-done:
-				return
+				// connection, which is established after ctx is done, is closed to avoid its leak
+				go closeSendMail(dialed)
+				return false
+			case sendMail := <-dialed:
+				if reflect2.IsNil(sendMail) {
+					return false
+				}
+				smMutex.Set(sendMail)
+				return true
 			}
 		}()
 
-		if reflect2.IsNil(smMutex.Get()) {
+		if connected {
+			mxHost = mx.Host
 			break
 		}
+		if c.CircuitBreaker != nil && parentCtx.Err() == nil {
+			c.CircuitBreaker.Failure(mx.Host)
+		}
 	}
 
-	stage := SafeSendMailStage{SendMailStage: ConnectionStage}
+	stage := SafeSendMailStage{SendMailStage: ConnectionStage, Trace: trace}
 	sm := smMutex.Get()
 	if reflect2.IsNil(sm) {
+		if len(openHosts) > 0 && len(openHosts) == len(mxs) {
+			return append(errs, NewError(CircuitOpenStage, &CircuitOpenError{Hosts: openHosts}))
+		}
 		return append(errs, ErrConnection)
 	}
 
+	if c.CircuitBreaker != nil && mxHost != "" {
+		defer func() {
+			// cancellation by caller is not failure of server
+			if parentCtx.Err() != nil {
+				return
+			}
+			if isResponseFailure(errs) {
+				c.CircuitBreaker.Failure(mxHost)
+			} else {
+				c.CircuitBreaker.Success(mxHost)
+			}
+		}()
+	}
+
 	needClose := abool.NewBool(true)
 	defer func() {
 		if needClose.IsNotSet() {
@@ -228,7 +321,7 @@ func (c CheckerStruct) Validate(mxs MXs, input Input) (errs []error) {
 	}
 
 	timeoutResponse := utils.DefaultDuration(input.TimeoutResponse(), c.Options.TimeoutResponse())
-	ctx := context.Background()
+	ctx := parentCtx
 	if timeoutResponse > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, timeoutResponse)
@@ -251,19 +344,24 @@ func (c CheckerStruct) Validate(mxs MXs, input Input) (errs []error) {
 		}
 
 		stage.Set(MailStage)
-		if err = sm.Mail(opts.EmailFrom().String()); err != nil {
+		if err = checkSMTPUTF8(sm, email, opts.EmailFrom()); err != nil {
+			errAppend(NewError(stage.Get(), err))
+			return
+		}
+		if err = sm.Mail(evmail.ASCIIAddress(opts.EmailFrom())); err != nil {
 			errAppend(NewError(stage.Get(), err))
 			return
 		}
 
 		stage.Set(RandomRCPTStage)
-		if errsRandomRCPTs := c.RandomRCPT.Call(sm, email); len(errsRandomRCPTs) > 0 {
+		if errsRandomRCPTs := c.randomRCPTMemo(ctx, sm, email); len(errsRandomRCPTs) > 0 {
 			if errAppend(errsRandomRCPTs...) {
 				return
 			}
 			stage.Set(RCPTsStage)
-			if errsRCPTs := sm.RCPTs([]string{email.String()}); len(errsRCPTs) > 0 {
-				errAppend(NewError(stage.Get(), errsRCPTs[email.String()]))
+			rcpt := evmail.ASCIIAddress(email)
+			if errsRCPTs := sm.RCPTs([]string{rcpt}); len(errsRCPTs) > 0 {
+				errAppend(NewError(stage.Get(), errsRCPTs[rcpt]))
 			}
 		}
 
@@ -284,8 +382,76 @@ func (c CheckerStruct) Validate(mxs MXs, input Input) (errs []error) {
 	}
 }
 
+// closeSendMail closes SendMail from dialed, if it was created
+func closeSendMail(dialed <-chan SendMail) {
+	sendMail := <-dialed
+	if reflect2.IsNil(sendMail) {
+		return
+	}
+	if err := sendMail.Close(); err != nil {
+		log.Logger().Error(fmt.Sprintf("SendMailStruct.Close %v", err))
+	}
+}
+
+// SMTPUTF8Extension is name of SMTP extension for internationalized mailboxes (RFC 6531)
+const SMTPUTF8Extension = "SMTPUTF8"
+
+// checkSMTPUTF8 returns SMTPUTF8Error, if one of mailboxes has non-ASCII local part and server does not support SMTPUTF8.
+// MAIL FROM is sent with SMTPUTF8 parameter by smtp.Client, if server supports it.
+func checkSMTPUTF8(sm SendMail, mailboxes ...evmail.Address) error {
+	for _, mailbox := range mailboxes {
+		if !evmail.RequiresSMTPUTF8(mailbox) {
+			continue
+		}
+
+		client := sm.Client()
+		if client == nil {
+			return nil
+		}
+		if ok, _ := client.Extension(SMTPUTF8Extension); !ok {
+			return &SMTPUTF8Error{Mailbox: mailbox.String()}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// randomRCPTMemoKey is key of evcache.Memo for RandomRCPT by domain
+type randomRCPTMemoKey string
+
+// randomRCPTMemo calls RandomRCPT once for each domain if ctx contains evcache.Memo.
+// RandomRCPT is called inline in the session of the first caller, so sm is not used after the caller returns.
+func (c CheckerStruct) randomRCPTMemo(ctx context.Context, sm SendMail, email evmail.Address) []error {
+	memo := evcache.MemoFromContext(ctx)
+	if memo == nil {
+		return callRandomRCPT(ctx, c.RandomRCPT, sm, email)
+	}
+
+	value, err := memo.DoInline(ctx, randomRCPTMemoKey(email.Domain()), func(ctx context.Context) (interface{}, error) {
+		// errors after cancellation of the first caller are not stored, other callers call RandomRCPT again
+		errs := callRandomRCPT(ctx, c.RandomRCPT, sm, email)
+		if err := ctx.Err(); err != nil {
+			return errs, err
+		}
+		for _, err := range errs {
+			if IsTransient(err) {
+				return errs, err
+			}
+		}
+
+		return errs, nil
+	})
+	if errs, ok := value.([]error); ok {
+		return errs
+	}
+
+	return []error{NewError(RandomRCPTStage, err)}
+}
+
 func (c CheckerStruct) randomRCPT(sm SendMail, email evmail.Address) (errs []error) {
-	randomEmail, err := c.RandomEmail(email.Domain())
+	randomEmail, err := c.RandomEmail(evmail.ASCIIDomain(email))
 	if err != nil {
 		randomEmailErr := NewError(RandomRCPTStage, err)
 		log.Logger().Error(
@@ -10,9 +10,10 @@ import (
 	"go.uber.org/zap"
 )
 
-// ARandomRCPT is abstract realization of RandomRCPT
+// ARandomRCPT is abstract realization of RandomRCPT and ContextRandomRCPT
 type ARandomRCPT struct {
-	fn RandomRCPTFunc
+	fn    RandomRCPTFunc
+	ctxFn RandomRCPTContextFunc
 }
 
 // Call is calling of RandomRCPTFunc
@@ -20,8 +21,26 @@ func (a *ARandomRCPT) Call(sm SendMail, email evmail.Address) []error {
 	return a.fn(sm, email)
 }
 
+// CallContext is calling of RandomRCPTContextFunc, RandomRCPTFunc is called without ctx if it is set by Set
+func (a *ARandomRCPT) CallContext(ctx context.Context, sm SendMail, email evmail.Address) []error {
+	if a.ctxFn == nil {
+		return a.Call(sm, email)
+	}
+
+	return a.ctxFn(ctx, sm, email)
+}
+
 func (a *ARandomRCPT) Set(fn RandomRCPTFunc) {
 	a.fn = fn
+	a.ctxFn = nil
+}
+
+// SetContext sets RandomRCPTContextFunc, Call uses it with context.Background()
+func (a *ARandomRCPT) SetContext(fn RandomRCPTContextFunc) {
+	a.fn = func(sm SendMail, email evmail.Address) []error {
+		return fn(context.Background(), sm, email)
+	}
+	a.ctxFn = fn
 }
 
 func (a *ARandomRCPT) Get() RandomRCPTFunc {
@@ -48,27 +67,57 @@ func NewCheckerCacheRandomRCPT(checker CheckerWithRandomRCPT, cache evcache.Inte
 		Cache:                 cache,
 		GetKey:                getKey,
 	}
+	if checkerStruct, ok := checker.(CheckerStruct); ok {
+		c.Hooks = checkerStruct.Hooks
+	}
 
-	c.CheckerWithRandomRCPT.Set(c.RandomRCPT)
+	if ctxRandomRCPT, ok := randomRCPTOf(checker).(ContextRandomRCPT); ok {
+		ctxRandomRCPT.SetContext(c.RandomRCPTContext)
+	} else {
+		c.CheckerWithRandomRCPT.Set(c.RandomRCPT)
+	}
 
 	return c
 }
 
+// randomRCPTOf returns RandomRCPT of CheckerStruct, because CheckerStruct has only methods of RandomRCPT
+func randomRCPTOf(checker CheckerWithRandomRCPT) RandomRCPT {
+	if checkerStruct, ok := checker.(CheckerStruct); ok {
+		return checkerStruct.RandomRCPT
+	}
+
+	return checker
+}
+
 type CheckerCacheRandomRCPTStruct struct {
 	CheckerWithRandomRCPT
 	RandomRCPTOpt RandomRCPT
 	Cache         evcache.Interface
 	GetKey        RandomCacheKeyGetter
+	// Hooks.Cache receives hits and misses of Cache
+	Hooks *Hooks
 }
 
+// ValidateContext calls ValidateContext of wrapped checker
+func (c CheckerCacheRandomRCPTStruct) ValidateContext(ctx context.Context, mxs MXs, input Input) []error {
+	return ValidateContext(ctx, c.CheckerWithRandomRCPT, mxs, input)
+}
+
+// RandomRCPT calls RandomRCPTContext without cancellation
 func (c CheckerCacheRandomRCPTStruct) RandomRCPT(sm SendMail, email evmail.Address) (errs []error) {
+	return c.RandomRCPTContext(context.Background(), sm, email)
+}
+
+// RandomRCPTContext returns cached errors of RandomRCPT or calls RandomRCPTOpt, ctx is passed to Cache and RandomRCPTOpt
+func (c CheckerCacheRandomRCPTStruct) RandomRCPTContext(ctx context.Context, sm SendMail, email evmail.Address) (errs []error) {
 	key := c.GetKey(email)
-	ctx := context.Background()
 	resultInterface, err := c.Cache.Get(ctx, key)
 	if err == nil && resultInterface != nil {
+		c.Hooks.cache(true)
 		errs = *resultInterface.(*[]error)
 	} else {
-		errs = c.RandomRCPTOpt.Call(sm, email)
+		c.Hooks.cache(false)
+		errs = callRandomRCPT(ctx, c.RandomRCPTOpt, sm, email)
 		if err = c.Cache.Set(ctx, key, ErrorsToEVSMTPErrors(errs)); err != nil {
 			log.Logger().Error(fmt.Sprintf("cache RandomRCPT: %s", err),
 				zap.String("email", email.String()),
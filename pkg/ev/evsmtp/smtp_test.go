@@ -352,9 +352,10 @@ func Test_checker_Validate(t *testing.T) {
 					dialFunc(t, simpleClient, nil, ctxTimeout, smtpLocalhost, "", 2*time.Millisecond),
 					nil,
 					func(client smtpclient.SMTPClient, tlsConfig *tls.Config) evsmtp.SendMail {
+						// connection established after timeout is only closed
 						return &mockSendMail{
 							t:    t,
-							want: failWant(nil, true),
+							want: []sendMailWant{closeStageWant},
 						}
 					}),
 				randomEmail: mockRandomEmail(t, randomAddress, nil),
@@ -415,6 +416,274 @@ func Test_checker_Validate(t *testing.T) {
 	}
 }
 
+func TestChecker_ValidateContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		SendMailFactory: evsmtp.NewSendMailCustom(
+			dialFunc(t, simpleClient, nil, ctx, smtpLocalhost, "", 0),
+			nil,
+			func(client smtpclient.SMTPClient, tlsConfig *tls.Config) evsmtp.SendMail {
+				return &mockSendMail{
+					t: t,
+					want: []sendMailWant{
+						{
+							sleep:   20 * time.Millisecond,
+							stage:   smHello,
+							message: smHelloLocalhost,
+							ret:     context.Canceled,
+						},
+						closeStageWant,
+					},
+				}
+			}),
+		RandomEmail: mockRandomEmail(t, randomAddress, nil),
+		Options:     &evsmtp.OptionsStruct{EmailFromOption: emailFrom},
+	})
+
+	time.AfterFunc(time.Millisecond, cancel)
+	gotErrs := evsmtp.ValidateContext(ctx, c, mxs, evsmtp.NewInput(emailTo, nil))
+	require.Equal(t, utils.Errs(evsmtp.NewError(evsmtp.HelloStage, context.Canceled)), gotErrs)
+}
+
+// closedSendMail closes closed on Close
+type closedSendMail struct {
+	*mockSendMail
+	closed chan struct{}
+}
+
+func (c closedSendMail) Close() error {
+	defer close(c.closed)
+
+	return c.mockSendMail.Close()
+}
+
+func TestChecker_ValidateContext_LateConnection(t *testing.T) {
+	closed := make(chan struct{})
+	c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		SendMailFactory: func(ctx context.Context, host string, opts evsmtp.Options) (evsmtp.SendMail, error) {
+			<-ctx.Done()
+
+			return closedSendMail{&mockSendMail{t: t, want: []sendMailWant{closeStageWant}}, closed}, nil
+		},
+		RandomEmail: mockRandomEmail(t, randomAddress, nil),
+		Options:     &evsmtp.OptionsStruct{EmailFromOption: emailFrom, TimeoutConOption: time.Millisecond},
+	})
+
+	gotErrs := evsmtp.ValidateContext(context.Background(), c, mxs[:1], evsmtp.NewInput(emailTo, nil))
+	require.Equal(t, utils.Errs(evsmtp.ErrConnection), gotErrs)
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("connection established after timeout is not closed")
+	}
+}
+
+func TestChecker_ValidateContext_RandomRCPTMemo(t *testing.T) {
+	startWants := failWant(&sendMailWant{
+		stage:   smRCPTs,
+		message: smRCPTs + randomAddress.String(),
+		ret:     errorSimple,
+	}, false)
+	rcptWant := sendMailWant{message: smRCPTs + emailTo.String()}
+	sendMailWants := [][]sendMailWant{
+		append(append([]sendMailWant{}, startWants...), rcptWant, quitStageWant),
+		append(append([]sendMailWant{}, startWants[:len(startWants)-1]...), rcptWant, quitStageWant),
+	}
+
+	calls := 0
+	c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		SendMailFactory: func(ctx context.Context, host string, opts evsmtp.Options) (evsmtp.SendMail, error) {
+			calls++
+			return &mockSendMail{t: t, want: sendMailWants[calls-1]}, nil
+		},
+		RandomEmail: mockRandomEmail(t, randomAddress, nil),
+		Options:     &evsmtp.OptionsStruct{EmailFromOption: emailFrom},
+	})
+
+	ctx := evcache.ContextWithMemo(context.Background(), evcache.NewMemo(evcache.MemoDTO{}))
+	wantErrs := utils.Errs(evsmtp.NewError(evsmtp.RandomRCPTStage, errorSimple))
+	for range sendMailWants {
+		gotErrs := evsmtp.ValidateContext(ctx, c, mxs, evsmtp.NewInput(emailTo, nil))
+		require.Equal(t, wantErrs, gotErrs)
+	}
+}
+
+// ctxCache records contexts of calls and misses values
+type ctxCache struct {
+	ctxs []context.Context
+}
+
+func (c *ctxCache) Get(ctx context.Context, _ interface{}) (interface{}, error) {
+	c.ctxs = append(c.ctxs, ctx)
+	return nil, errorSimple
+}
+
+func (c *ctxCache) Set(ctx context.Context, _, _ interface{}) error {
+	c.ctxs = append(c.ctxs, ctx)
+	return nil
+}
+
+type ctxTestKey struct{}
+
+func TestCheckerCacheRandomRCPT_ValidateContext(t *testing.T) {
+	c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		SendMailFactory: func(ctx context.Context, host string, opts evsmtp.Options) (evsmtp.SendMail, error) {
+			want := failWant(&sendMailWant{
+				stage:   smRCPTs,
+				message: smRCPTs + randomAddress.String(),
+				ret:     errorSimple,
+			}, false)
+			return &mockSendMail{t: t, want: append(want, sendMailWant{message: smRCPTs + emailTo.String()}, quitStageWant)}, nil
+		},
+		RandomEmail: mockRandomEmail(t, randomAddress, nil),
+		Options:     &evsmtp.OptionsStruct{EmailFromOption: emailFrom},
+	}).(evsmtp.CheckerWithRandomRCPT)
+	cache := &ctxCache{}
+	checker := evsmtp.NewCheckerCacheRandomRCPT(c, cache, nil)
+
+	ctx := context.WithValue(context.Background(), ctxTestKey{}, true)
+	gotErrs := evsmtp.ValidateContext(ctx, checker, mxs, evsmtp.NewInput(emailTo, nil))
+	require.Equal(t, utils.Errs(evsmtp.NewError(evsmtp.RandomRCPTStage, errorSimple)), gotErrs)
+
+	require.Len(t, cache.ctxs, 2)
+	for _, cacheCtx := range cache.ctxs {
+		require.Equal(t, true, cacheCtx.Value(ctxTestKey{}), "ctx of caller should be passed to cache")
+	}
+}
+
+func TestChecker_Validate_SMTPUTF8(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	successDialFunc := dialFunc(t, simpleClient, nil, context.Background(), smtpLocalhost, "", 0)
+	newClient := func(smtpUTF8 bool) smtpclient.SMTPClient {
+		client := NewMockSMTPClient(ctrl)
+		client.EXPECT().Extension(evsmtp.SMTPUTF8Extension).Return(smtpUTF8, "").Times(1)
+		return client
+	}
+
+	emailUTF8 := evmail.FromString("josé@bücher.de")
+	emailUTF8ASCII := "josé@xn--bcher-kva.de"
+	randomUTF8Address := evmail.FromString("random.which.did.not.exist@xn--bcher-kva.de")
+	emailFromUTF8 := evmail.FromString("andré@from.com")
+
+	tests := []struct {
+		name      string
+		emailFrom evmail.Address
+		email     evmail.Address
+		want      []sendMailWant
+		wantErrs  []error
+	}{
+		{
+			name:      "server without SMTPUTF8",
+			emailFrom: emailFrom,
+			email:     emailUTF8,
+			want:      failWant(&sendMailWant{stage: smMail, message: smClient, ret: newClient(false)}, true),
+			wantErrs: utils.Errs(evsmtp.NewError(evsmtp.MailStage, &evsmtp.SMTPUTF8Error{
+				Mailbox: emailUTF8.String(),
+			})),
+		},
+		{
+			name:      "sender without SMTPUTF8",
+			emailFrom: emailFromUTF8,
+			email:     emailTo,
+			want:      failWant(&sendMailWant{stage: smMail, message: smClient, ret: newClient(false)}, true),
+			wantErrs: utils.Errs(evsmtp.NewError(evsmtp.MailStage, &evsmtp.SMTPUTF8Error{
+				Mailbox: emailFromUTF8.String(),
+			})),
+		},
+		{
+			name:      "server with SMTPUTF8",
+			emailFrom: emailFrom,
+			email:     emailUTF8,
+			want: append(failWant(&sendMailWant{stage: smMail, message: smClient, ret: newClient(true)}, false),
+				defaultWantMap[smMail],
+				sendMailWant{
+					stage:   smRCPTs,
+					message: smRCPTs + randomUTF8Address.String(),
+					ret:     errorSimple,
+				},
+				sendMailWant{
+					stage:   smRCPTs,
+					message: smRCPTs + emailUTF8ASCII,
+				},
+				quitStageWant,
+			),
+			wantErrs: utils.Errs(evsmtp.NewError(evsmtp.RandomRCPTStage, errorSimple)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+				SendMailFactory: evsmtp.NewSendMailCustom(successDialFunc, nil,
+					func(client smtpclient.SMTPClient, tlsConfig *tls.Config) evsmtp.SendMail {
+						return &mockSendMail{t: t, want: tt.want}
+					}),
+				RandomEmail: mockRandomEmail(t, randomUTF8Address, nil),
+				Options:     &evsmtp.OptionsStruct{EmailFromOption: tt.emailFrom},
+			})
+
+			gotErrs := c.Validate(mxs, evsmtp.NewInput(tt.email, nil))
+			require.Equal(t, tt.wantErrs, gotErrs)
+		})
+	}
+}
+
+func TestChecker_Validate_SMTPUTF8_Local(t *testing.T) {
+	server := []string{
+		"220 hello world",
+		"250-mx.example.org at your service",
+		"250 SMTPUTF8",
+		"250 Sender ok",
+		"550 address does not exist",
+		"250 Receiver ok",
+		"221 Goodbye",
+	}
+	wantSMTP := []string{
+		"EHLO helloName",
+		"MAIL FROM:<user@example.org> SMTPUTF8",
+		"RCPT TO:<random.which.did.not.exist@xn--bcher-kva.de>",
+		"RCPT TO:<josé@xn--bcher-kva.de>",
+		"QUIT",
+		"",
+	}
+
+	addr, done := Server(t, server, time.Second, "", false)
+	u, _ := url.Parse("http://" + addr)
+	port, _ := strconv.Atoi(u.Port())
+
+	c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		RandomEmail: mockRandomEmail(t, evmail.FromString("random.which.did.not.exist@xn--bcher-kva.de"), nil),
+		Options: evsmtp.NewOptions(evsmtp.OptionsDTO{
+			EmailFrom: evmail.FromString(evsmtp.DefaultEmail),
+			HelloName: helloName,
+			Port:      port,
+		}),
+	})
+
+	gotErrs := c.Validate(mxs, evsmtp.NewInput(evmail.FromString("josé@bücher.de"), nil))
+	require.Equal(t, strings.Join(wantSMTP, Separator), <-done)
+	require.Equal(t, utils.Errs(evsmtp.NewError(evsmtp.RandomRCPTStage, &textproto.Error{
+		Code: 550,
+		Msg:  "address does not exist",
+	})), gotErrs)
+}
+
+func TestValidateContext_WithoutContextChecker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	input := evsmtp.NewInput(emailTo, nil)
+	checker := NewMockChecker(ctrl)
+	checker.EXPECT().Validate(mxs, input).Return(utils.Errs(errorSimple)).Times(1)
+
+	gotErrs := evsmtp.ValidateContext(context.Background(), checker, mxs, input)
+	require.Equal(t, utils.Errs(errorSimple), gotErrs)
+}
+
 func TestChecker_Validate_WithProxy_Local(t *testing.T) {
 	evtests.FunctionalSkip(t)
 
@@ -543,6 +812,7 @@ func Test_checkerCacheRandomRCPT_RandomRCPT(t *testing.T) {
 		fields   fields
 		args     args
 		wantErrs []error
+		wantHit  bool
 	}{
 		{
 			name: "with cache",
@@ -560,6 +830,7 @@ func Test_checkerCacheRandomRCPT_RandomRCPT(t *testing.T) {
 				email: validEmail,
 			},
 			wantErrs: errs,
+			wantHit:  true,
 		},
 		{
 			name: "without cache",
@@ -590,9 +861,14 @@ func Test_checkerCacheRandomRCPT_RandomRCPT(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := evsmtp.NewCheckerCacheRandomRCPT(tt.fields.checkerWithRandomRPCT(), tt.fields.cache(), tt.fields.getKey).(*evsmtp.CheckerCacheRandomRCPTStruct)
+			var gotHits []bool
+			c.Hooks = &evsmtp.Hooks{Cache: func(hit bool) {
+				gotHits = append(gotHits, hit)
+			}}
 			if gotErrs := c.RandomRCPT(nil, tt.args.email); !reflect.DeepEqual(gotErrs, tt.wantErrs) {
 				t.Errorf("RandomRCPT() = %v, want %v", gotErrs, tt.wantErrs)
 			}
+			require.Equal(t, []bool{tt.wantHit}, gotHits)
 		})
 	}
 }
@@ -0,0 +1,133 @@
+package evsmtp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var stageNames = map[SendMailStage]string{
+	ClientStage:      "client",
+	HelloStage:       "hello",
+	AuthStage:        "auth",
+	MailStage:        "mail",
+	RCPTsStage:       "rcpts",
+	QuitStage:        "quit",
+	CloseStage:       "close",
+	RandomRCPTStage:  "randomRCPT",
+	ConnectionStage:  "connection",
+	CircuitOpenStage: "circuitOpen",
+}
+
+// StageName returns name of stage, it is used in StageTiming
+func StageName(stage SendMailStage) string {
+	return stageNames[stage]
+}
+
+// StageByName returns stage by its name from StageName
+func StageByName(name string) (SendMailStage, bool) {
+	for stage, stageName := range stageNames {
+		if stageName == name {
+			return stage, true
+		}
+	}
+
+	return 0, false
+}
+
+// StageTiming is time spent on one stage of Checker
+type StageTiming struct {
+	Stage    SendMailStage `json:"stage"`
+	Name     string        `json:"name"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+}
+
+// NewStagesTrace instantiates StagesTrace
+func NewStagesTrace() *StagesTrace {
+	return &StagesTrace{}
+}
+
+// StagesTrace collects transitions of SafeSendMailStage
+type StagesTrace struct {
+	mu      sync.Mutex
+	timings []StageTiming
+	active  bool
+	stopped bool
+}
+
+// Start finishes the current stage and starts the next one
+func (s *StagesTrace) Start(stage SendMailStage) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return
+	}
+
+	now := time.Now()
+	s.finish(now)
+	s.timings = append(s.timings, StageTiming{
+		Stage: stage,
+		Name:  StageName(stage),
+		Start: now,
+	})
+	s.active = true
+}
+
+// Finish finishes the current stage and stops recording, stages of abandoned connections are ignored
+func (s *StagesTrace) Finish() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.finish(time.Now())
+	s.stopped = true
+}
+
+func (s *StagesTrace) finish(now time.Time) {
+	if !s.active {
+		return
+	}
+
+	last := &s.timings[len(s.timings)-1]
+	last.Duration = now.Sub(last.Start)
+	s.active = false
+}
+
+// Timings returns copy of collected timings
+func (s *StagesTrace) Timings() []StageTiming {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.timings) == 0 {
+		return nil
+	}
+
+	return append([]StageTiming{}, s.timings...)
+}
+
+type stagesTraceKey struct{}
+
+// ContextWithStagesTrace returns ctx, which carries trace. CheckerStruct records timings of stages into it.
+func ContextWithStagesTrace(ctx context.Context, trace *StagesTrace) context.Context {
+	return context.WithValue(ctx, stagesTraceKey{}, trace)
+}
+
+// StagesTraceFromContext returns StagesTrace from ctx or nil
+func StagesTraceFromContext(ctx context.Context) *StagesTrace {
+	trace, _ := ctx.Value(stagesTraceKey{}).(*StagesTrace)
+
+	return trace
+}
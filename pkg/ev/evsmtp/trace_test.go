@@ -0,0 +1,54 @@
+package evsmtp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStagesTrace(t *testing.T) {
+	trace := evsmtp.NewStagesTrace()
+	trace.Start(evsmtp.ConnectionStage)
+	trace.Start(evsmtp.HelloStage)
+	trace.Finish()
+	trace.Start(evsmtp.QuitStage)
+
+	got := trace.Timings()
+	require.Len(t, got, 2)
+	require.Equal(t, evsmtp.ConnectionStage, got[0].Stage)
+	require.Equal(t, "connection", got[0].Name)
+	require.Equal(t, got[0].Duration, got[1].Start.Sub(got[0].Start))
+	require.Equal(t, evsmtp.HelloStage, got[1].Stage)
+	require.Equal(t, "hello", got[1].Name)
+}
+
+func TestStagesTrace_Nil(t *testing.T) {
+	var trace *evsmtp.StagesTrace
+	trace.Start(evsmtp.HelloStage)
+	trace.Finish()
+
+	require.Nil(t, trace.Timings())
+	require.Nil(t, evsmtp.StagesTraceFromContext(context.Background()))
+}
+
+func TestChecker_ValidateContext_StagesTrace(t *testing.T) {
+	c := evsmtp.NewChecker(evsmtp.CheckerDTO{
+		SendMailFactory: func(ctx context.Context, host string, opts evsmtp.Options) (evsmtp.SendMail, error) {
+			return &mockSendMail{t: t, want: failWant(nil, true)}, nil
+		},
+		RandomEmail: mockRandomEmail(t, randomAddress, nil),
+		Options:     &evsmtp.OptionsStruct{EmailFromOption: emailFrom},
+	})
+
+	trace := evsmtp.NewStagesTrace()
+	ctx := evsmtp.ContextWithStagesTrace(context.Background(), trace)
+	require.Empty(t, evsmtp.ValidateContext(ctx, c, mxs, evsmtp.NewInput(emailTo, nil)))
+
+	names := make([]string, 0)
+	for _, timing := range trace.Timings() {
+		names = append(names, timing.Name)
+	}
+	require.Equal(t, []string{"connection", "hello", "auth", "mail", "randomRCPT", "quit"}, names)
+}
@@ -0,0 +1,106 @@
+package evsuggest
+
+import (
+	"math"
+)
+
+// Costs of edits of KeyboardDistance
+const (
+	// AdjacentCost is cost of substitution of adjacent keys, e.g. gmaul.com for gmail.com
+	AdjacentCost = 0.5
+	// TranspositionCost is cost of swap of neighbour characters, e.g. gmial.com for gmail.com
+	TranspositionCost = 0.5
+	// EditCost is cost of other substitutions, insertions and deletions
+	EditCost = 1.0
+)
+
+// Keyboard is map of keys to their adjacent keys
+type Keyboard map[rune]string
+
+// Adjacent checks, whether keys a and b are adjacent
+func (k Keyboard) Adjacent(a, b rune) bool {
+	for _, key := range k[a] {
+		if key == b {
+			return true
+		}
+	}
+
+	return false
+}
+
+// QWERTY returns Keyboard of QWERTY layout, it contains lowercase letters, digits, "-" and "."
+func QWERTY() Keyboard {
+	rows := []string{
+		"1234567890-",
+		"qwertyuiop",
+		"asdfghjkl",
+		"zxcvbnm,.",
+	}
+	// offsets of rows, e.g. "a" is between "q" and "w"
+	offsets := []float64{0, 0.5, 0.75, 1.25}
+	// "," keeps position of ".", but it is not in domains
+	const skip = ','
+
+	keyboard := make(Keyboard)
+	for i, row := range rows {
+		for j, key := range row {
+			if key == skip {
+				continue
+			}
+			pos := offsets[i] + float64(j)
+			for k := i - 1; k <= i+1; k++ {
+				if k < 0 || k >= len(rows) {
+					continue
+				}
+				for l, other := range rows[k] {
+					if other == key || other == skip {
+						continue
+					}
+					otherPos := offsets[k] + float64(l)
+					if (k == i && math.Abs(otherPos-pos) == 1) || (k != i && math.Abs(otherPos-pos) < 1) {
+						keyboard[key] += string(other)
+					}
+				}
+			}
+		}
+	}
+
+	return keyboard
+}
+
+// KeyboardDistance is optimal string alignment distance, where substitutions of adjacent keys
+// cost AdjacentCost and transpositions cost TranspositionCost
+func KeyboardDistance(keyboard Keyboard, a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]float64, len(ra)+1)
+	for i := range d {
+		d[i] = make([]float64, len(rb)+1)
+		d[i][0] = float64(i) * EditCost
+	}
+	for j := range d[0] {
+		d[0][j] = float64(j) * EditCost
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			substitution := 0.0
+			switch {
+			case ra[i-1] == rb[j-1]:
+			case keyboard.Adjacent(ra[i-1], rb[j-1]):
+				substitution = AdjacentCost
+			default:
+				substitution = EditCost
+			}
+
+			d[i][j] = math.Min(
+				math.Min(d[i-1][j]+EditCost, d[i][j-1]+EditCost),
+				d[i-1][j-1]+substitution,
+			)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = math.Min(d[i][j], d[i-2][j-2]+TranspositionCost)
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,44 @@
+package evsuggest_test
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsuggest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQWERTY(t *testing.T) {
+	keyboard := evsuggest.QWERTY()
+
+	require.Equal(t, "qwsz", keyboard['a'])
+	require.Equal(t, "12wa", keyboard['q'])
+	require.Equal(t, "jkn", keyboard['m'])
+	require.Equal(t, "l", keyboard['.'])
+	require.NotContains(t, keyboard, ',')
+	require.True(t, keyboard.Adjacent('i', 'o'))
+	require.False(t, keyboard.Adjacent('i', 'a'))
+}
+
+func TestKeyboardDistance(t *testing.T) {
+	keyboard := evsuggest.QWERTY()
+
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{a: "gmail.com", b: "gmail.com", want: 0},
+		{a: "gmaul.com", b: "gmail.com", want: evsuggest.AdjacentCost},
+		{a: "gmial.com", b: "gmail.com", want: evsuggest.TranspositionCost},
+		{a: "gmazl.com", b: "gmail.com", want: evsuggest.EditCost},
+		{a: "gmai.com", b: "gmail.com", want: evsuggest.EditCost},
+		{a: "gmaill.com", b: "gmail.com", want: evsuggest.EditCost},
+		{a: "", b: "com", want: 3 * evsuggest.EditCost},
+		{a: "bücher", b: "bucher", want: evsuggest.EditCost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			require.Equal(t, tt.want, evsuggest.KeyboardDistance(keyboard, tt.a, tt.b))
+			require.Equal(t, tt.want, evsuggest.KeyboardDistance(keyboard, tt.b, tt.a))
+		})
+	}
+}
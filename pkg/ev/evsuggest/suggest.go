@@ -0,0 +1,206 @@
+package evsuggest
+
+import (
+	"strings"
+
+	"github.com/hbollon/go-edlib"
+	"github.com/prodadidb/go-email-validator/pkg/ev/free"
+)
+
+// Defaults of SuggesterDTO
+const (
+	DefaultMaxDistance     = 2
+	DefaultFreeMaxDistance = 1
+	DefaultMinConfidence   = 0.8
+)
+
+// DefaultPopularDomains returns popular email domains, they are preferred to other domains with the same distance
+func DefaultPopularDomains() []string {
+	return []string{
+		"gmail.com", "yahoo.com", "hotmail.com", "outlook.com", "aol.com", "icloud.com", "live.com",
+		"msn.com", "me.com", "mac.com", "googlemail.com", "protonmail.com", "proton.me", "zoho.com",
+		"yandex.ru", "mail.ru", "gmx.com", "gmx.de", "web.de", "comcast.net", "verizon.net",
+		"att.net", "sbcglobal.net", "hotmail.co.uk", "yahoo.co.uk", "live.co.uk", "qq.com", "163.com",
+	}
+}
+
+// DefaultTLDs returns common top-level domains, TLDs of domains are known too
+func DefaultTLDs() []string {
+	return []string{
+		"com", "net", "org", "edu", "gov", "mil", "int", "info", "biz", "io", "co", "me", "app", "dev",
+		"us", "uk", "ca", "au", "de", "fr", "es", "it", "nl", "be", "ch", "at", "se", "no", "dk", "fi",
+		"pl", "cz", "ru", "ua", "jp", "cn", "in", "br", "mx", "ar", "za", "nz", "ie", "pt", "gr", "tr",
+	}
+}
+
+// Suggestion is suggested domain instead of misspelled domain
+type Suggestion struct {
+	Domain string
+	// Confidence is from 0 to 1, it is 1 minus KeyboardDistance divided by length of the longer domain or TLD
+	Confidence float64
+}
+
+// SuggesterDTO is DTO for NewSuggester
+type SuggesterDTO struct {
+	// Domains are popular domains, DefaultPopularDomains() if nil
+	Domains []string
+	// WithoutFree excludes free.WillWhiteFree() from known domains
+	WithoutFree bool
+	// TLDs are DefaultTLDs() if nil
+	TLDs []string
+	// MaxDistance is maximal number of edits by optimal string alignment, DefaultMaxDistance if 0
+	MaxDistance int
+	// FreeMaxDistance is MaxDistance for free domains, which are not popular, DefaultFreeMaxDistance if 0.
+	// The list of free domains contains rare domains, which are similar to many other domains.
+	FreeMaxDistance int
+	// MinConfidence is DefaultMinConfidence if 0
+	MinConfidence float64
+	// Keyboard is QWERTY() if nil
+	Keyboard Keyboard
+}
+
+// NewSuggester instantiates Suggester
+func NewSuggester(dto SuggesterDTO) *Suggester {
+	if dto.Domains == nil {
+		dto.Domains = DefaultPopularDomains()
+	}
+	if dto.TLDs == nil {
+		dto.TLDs = DefaultTLDs()
+	}
+	if dto.MaxDistance == 0 {
+		dto.MaxDistance = DefaultMaxDistance
+	}
+	if dto.FreeMaxDistance == 0 {
+		dto.FreeMaxDistance = DefaultFreeMaxDistance
+	}
+	if dto.MinConfidence == 0 {
+		dto.MinConfidence = DefaultMinConfidence
+	}
+	if dto.Keyboard == nil {
+		dto.Keyboard = QWERTY()
+	}
+
+	domains := dto.Domains
+	if !dto.WithoutFree {
+		domains = append(append([]string{}, domains...), free.WillWhiteFree()...)
+	}
+
+	s := &Suggester{
+		dto:     dto,
+		known:   make(map[string]bool, len(domains)),
+		tldsSet: make(map[string]bool, len(dto.TLDs)),
+	}
+	for i, domain := range domains {
+		domain = strings.ToLower(domain)
+		if s.known[domain] {
+			continue
+		}
+		s.known[domain] = true
+		s.domains = append(s.domains, domain)
+		if i < len(dto.Domains) {
+			s.popular++
+		}
+
+		if tld := tldOf(domain); tld != "" && !s.tldsSet[tld] {
+			s.tldsSet[tld] = true
+			s.tlds = append(s.tlds, tld)
+		}
+	}
+	for _, tld := range dto.TLDs {
+		tld = strings.ToLower(tld)
+		if !s.tldsSet[tld] {
+			s.tldsSet[tld] = true
+			s.tlds = append(s.tlds, tld)
+		}
+	}
+
+	return s
+}
+
+// Suggester suggests known domains for misspelled domains by edit distance and keyboard adjacency
+type Suggester struct {
+	dto SuggesterDTO
+	// domains are popular domains first, then free domains
+	domains []string
+	popular int
+	known   map[string]bool
+	tlds    []string
+	tldsSet map[string]bool
+}
+
+// Suggest returns the best suggestion for domain, ok is false if domain is known or similar domains are not found.
+// Whole domain is compared with known domains, then TLD is compared with known TLDs.
+func (s *Suggester) Suggest(domain string) (suggestion Suggestion, ok bool) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if domain == "" || s.known[domain] {
+		return Suggestion{}, false
+	}
+
+	if suggestion, ok = s.best(domain, s.domains, s.popular); ok {
+		return suggestion, true
+	}
+
+	tld := tldOf(domain)
+	if tld == "" || s.tldsSet[tld] {
+		return Suggestion{}, false
+	}
+	if suggestion, ok = s.best(tld, s.tlds, len(s.tlds)); ok {
+		suggestion.Domain = strings.TrimSuffix(domain, tld) + suggestion.Domain
+		return suggestion, true
+	}
+
+	return Suggestion{}, false
+}
+
+// best returns candidate with the highest confidence, the first candidate is chosen from equal ones.
+// The first popular candidates are limited by MaxDistance, others are limited by FreeMaxDistance.
+func (s *Suggester) best(value string, candidates []string, popular int) (best Suggestion, ok bool) {
+	length := len([]rune(value))
+	for i, candidate := range candidates {
+		maxDistance := s.dto.MaxDistance
+		if i >= popular {
+			maxDistance = s.dto.FreeMaxDistance
+		}
+
+		candidateLength := len([]rune(candidate))
+		if abs(candidateLength-length) > maxDistance {
+			continue
+		}
+		if edlib.OSADamerauLevenshteinDistance(value, candidate) > maxDistance {
+			continue
+		}
+
+		confidence := 1 - KeyboardDistance(s.dto.Keyboard, value, candidate)/float64(maxInt(length, candidateLength))
+		if confidence >= s.dto.MinConfidence && confidence > best.Confidence {
+			best, ok = Suggestion{Domain: candidate, Confidence: confidence}, true
+		}
+	}
+
+	return best, ok
+}
+
+// tldOf returns the last label of domain or empty string for domains without dots
+func tldOf(domain string) string {
+	pos := strings.LastIndexByte(domain, '.')
+	if pos == -1 {
+		return ""
+	}
+
+	return domain[pos+1:]
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,70 @@
+package evsuggest_test
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsuggest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggester_Suggest(t *testing.T) {
+	suggester := evsuggest.NewSuggester(evsuggest.SuggesterDTO{})
+
+	tests := []struct {
+		domain string
+		want   evsuggest.Suggestion
+		wantOk bool
+	}{
+		{domain: "gmial.com", want: evsuggest.Suggestion{Domain: "gmail.com", Confidence: 1 - 0.5/9}, wantOk: true},
+		{domain: "Gmaul.com.", want: evsuggest.Suggestion{Domain: "gmail.com", Confidence: 1 - 0.5/9}, wantOk: true},
+		{domain: "gmai.com", want: evsuggest.Suggestion{Domain: "gmail.com", Confidence: 1 - 1.0/9}, wantOk: true},
+		{domain: "hotmial.com", want: evsuggest.Suggestion{Domain: "hotmail.com", Confidence: 1 - 0.5/11}, wantOk: true},
+		{domain: "outlok.com", want: evsuggest.Suggestion{Domain: "outlook.com", Confidence: 1 - 1.0/11}, wantOk: true},
+		{domain: "gmail.con", want: evsuggest.Suggestion{Domain: "gmail.com", Confidence: 1 - 0.5/9}, wantOk: true},
+		{domain: "example.con", want: evsuggest.Suggestion{Domain: "example.com", Confidence: 1 - 0.5/3}, wantOk: true},
+		{domain: "yahoo.co.uj", want: evsuggest.Suggestion{Domain: "yahoo.co.uk", Confidence: 1 - 0.5/11}, wantOk: true},
+		{domain: "itymail.com"},
+		{domain: "gmail.com"},
+		{domain: "gmx.de"},
+		{domain: "example.com"},
+		{domain: "mycompany.io"},
+		{domain: "company.xyz"},
+		{domain: "localhost"},
+		{domain: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			got, ok := suggester.Suggest(tt.domain)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.want.Domain, got.Domain)
+			require.InDelta(t, tt.want.Confidence, got.Confidence, 1e-9)
+		})
+	}
+}
+
+func TestSuggester_Suggest_Custom(t *testing.T) {
+	suggester := evsuggest.NewSuggester(evsuggest.SuggesterDTO{
+		Domains:       []string{"Example.com", "example.org"},
+		WithoutFree:   true,
+		TLDs:          []string{"xyz"},
+		MaxDistance:   1,
+		MinConfidence: 0.5,
+	})
+
+	got, ok := suggester.Suggest("exanple.com")
+	require.True(t, ok)
+	require.Equal(t, "example.com", got.Domain)
+
+	_, ok = suggester.Suggest("exmpl.com")
+	require.False(t, ok, "distance is greater than MaxDistance")
+
+	_, ok = suggester.Suggest("gmial.com")
+	require.False(t, ok, "free domains are excluded")
+
+	got, ok = suggester.Suggest("company.xyy")
+	require.True(t, ok)
+	require.Equal(t, "company.xyz", got.Domain)
+
+	_, ok = suggester.Suggest("company.com")
+	require.False(t, ok, "TLD of domains is known")
+}
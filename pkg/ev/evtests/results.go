@@ -0,0 +1,85 @@
+package evtests
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+)
+
+// SMTPErrsVariants returns typical lists of errors of SMTPValidator
+func SMTPErrsVariants() [][]error {
+	rejected := &textproto.Error{Code: 550, Msg: "user unknown"}
+	return [][]error{
+		nil,
+		{ev.NewDepsError()},
+		{evsmtp.ErrConnection},
+		{evsmtp.NewError(evsmtp.HelloStage, rejected)},
+		{evsmtp.NewError(evsmtp.RandomRCPTStage, rejected)},
+		{evsmtp.NewError(evsmtp.RandomRCPTStage, rejected), evsmtp.NewError(evsmtp.RCPTsStage, rejected)},
+		{evsmtp.NewError(evsmtp.RandomRCPTStage, rejected), evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "account disabled"})},
+		{evsmtp.NewError(evsmtp.RandomRCPTStage, rejected), evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 452, Msg: "mailbox full"})},
+		{evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{
+			Code: 450,
+			Msg:  "the user you are trying to contact is receiving mail at a rate that prevents additional messages",
+		})},
+	}
+}
+
+// DepResultsCombinations returns results of DepValidator with combinations of valid and invalid
+// results of syntax, MX, disposable, free and role validators and SMTPErrsVariants.
+// Results of black list of emails and ban words validators are valid.
+func DepResultsCombinations() []ev.ValidationResult {
+	names := []ev.ValidatorName{
+		ev.SyntaxValidatorName,
+		ev.MXValidatorName,
+		ev.DisposableValidatorName,
+		ev.FreeValidatorName,
+		ev.RoleValidatorName,
+	}
+
+	combinations := make([]ev.ValidationResult, 0)
+	for mask := 0; mask < 1<<len(names); mask++ {
+		for _, smtpErrs := range SMTPErrsVariants() {
+			results := ev.DepResult{
+				ev.BlackListEmailsValidatorName:  ev.NewValidResult(ev.BlackListEmailsValidatorName),
+				ev.BanWordsUsernameValidatorName: ev.NewValidResult(ev.BanWordsUsernameValidatorName),
+			}
+			isValid := len(smtpErrs) == 0
+			for i, name := range names {
+				valid := mask&(1<<i) == 0
+				isValid = isValid && valid
+				results[name] = ev.NewResult(valid, nil, nil, name)
+			}
+			results[ev.MXValidatorName] = ev.NewMXValidationResult(
+				evsmtp.MXs{&net.MX{Host: "mx.example.com"}},
+				results[ev.MXValidatorName].(*ev.AValidationResult),
+			)
+			results[ev.SMTPValidatorName] = ev.NewResult(len(smtpErrs) == 0, smtpErrs, nil, ev.SMTPValidatorName)
+
+			combinations = append(combinations, ev.NewDepValidatorResult(isValid, results))
+		}
+	}
+
+	return combinations
+}
+
+// MXTimeoutDepResult returns result of DepValidator with default validators and FreeValidatorName,
+// where MXValidatorName times out, so its result is not ev.MXValidationResult and SMTPValidatorName gets ev.DepsError
+func MXTimeoutDepResult(email string) ev.ValidationResult {
+	mxValidator := ev.NewMXValidatorContext(func(ctx context.Context, _ string) (evsmtp.MXs, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	return ev.NewDepBuilder(nil).
+		Set(ev.MXValidatorName, mxValidator).
+		Set(ev.FreeValidatorName, ev.FreeDefaultValidator()).
+		SetTimeout(ev.MXValidatorName, time.Millisecond).
+		Build().
+		Validate(ev.NewInput(evmail.FromString(email)))
+}
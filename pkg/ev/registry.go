@@ -0,0 +1,227 @@
+package ev
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Factory instantiates validator with params
+type Factory interface {
+	// Name is name of instantiated validator
+	Name() ValidatorName
+	Description() string
+	// Params returns pointer to new params with default values, it is filled by ParamsDecoder and passed to New
+	Params() interface{}
+	// New instantiates validator, params are default if nil
+	New(params interface{}) (Validator, error)
+}
+
+// ParamsValidator is implemented by params, which are checked by Registry before Factory.New
+type ParamsValidator interface {
+	Validate() error
+}
+
+// ParamsDecoder fills params, e.g. by json.Unmarshal or yaml.Node.Decode
+type ParamsDecoder func(params interface{}) error
+
+// NewFactory instantiates Factory with params of type P, create gets defaults if params are not set
+func NewFactory[P any](name ValidatorName, description string, defaults P, create func(params P) (Validator, error)) Factory {
+	return factory[P]{
+		name:        name,
+		description: description,
+		defaults:    defaults,
+		create:      create,
+	}
+}
+
+type factory[P any] struct {
+	name        ValidatorName
+	description string
+	defaults    P
+	create      func(params P) (Validator, error)
+}
+
+func (f factory[P]) Name() ValidatorName {
+	return f.name
+}
+
+func (f factory[P]) Description() string {
+	return f.description
+}
+
+func (f factory[P]) Params() interface{} {
+	params := f.defaults
+	return &params
+}
+
+func (f factory[P]) New(params interface{}) (validator Validator, err error) {
+	switch p := params.(type) {
+	case nil:
+		validator, err = f.create(f.defaults)
+	case P:
+		validator, err = f.create(p)
+	case *P:
+		validator, err = f.create(*p)
+	default:
+		err = &ParamsError{Reason: fmt.Sprintf("unexpected params %T, %T is expected", params, f.defaults)}
+	}
+
+	return validator, withValidatorName(f.name, err)
+}
+
+// ParamsError is returned if params of Factory are invalid
+type ParamsError struct {
+	Validator ValidatorName
+	// Field is path to invalid field of params, e.g. smtp.port
+	Field  string
+	Reason string
+}
+
+func (p *ParamsError) Error() string {
+	if p.Field == "" {
+		return fmt.Sprintf("ParamsError: %s: %s", p.Validator, p.Reason)
+	}
+
+	return fmt.Sprintf("ParamsError: %s: %s: %s", p.Validator, p.Field, p.Reason)
+}
+
+// UnknownFactoryError is returned if there is no Factory with name in Registry
+type UnknownFactoryError struct {
+	Name ValidatorName
+}
+
+func (u *UnknownFactoryError) Error() string {
+	return fmt.Sprintf("UnknownFactoryError: validator %q is not registered", u.Name)
+}
+
+// DuplicateFactoryError is returned if Factory with name is already registered
+type DuplicateFactoryError struct {
+	Name ValidatorName
+}
+
+func (d *DuplicateFactoryError) Error() string {
+	return fmt.Sprintf("DuplicateFactoryError: validator %q is already registered", d.Name)
+}
+
+// NewRegistry instantiates Registry with factories, it panics on duplicate names
+func NewRegistry(factories ...Factory) *Registry {
+	r := &Registry{factories: make(map[ValidatorName]Factory, len(factories))}
+	r.MustRegister(factories...)
+
+	return r
+}
+
+// Registry contains factories by names of validators, it is safe for concurrent use
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[ValidatorName]Factory
+}
+
+// Register adds factory, DuplicateFactoryError is returned if the name is already registered
+func (r *Registry) Register(factory Factory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.factories[factory.Name()]; ok {
+		return &DuplicateFactoryError{Name: factory.Name()}
+	}
+	r.factories[factory.Name()] = factory
+
+	return nil
+}
+
+// MustRegister adds factories and panics on error, it is useful in init functions of packages
+func (r *Registry) MustRegister(factories ...Factory) {
+	for _, factory := range factories {
+		if err := r.Register(factory); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Get returns Factory by name
+func (r *Registry) Get(name ValidatorName) (Factory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	factory, ok := r.factories[name]
+	return factory, ok
+}
+
+// Names returns names of registered validators in alphabetical order
+func (r *Registry) Names() []ValidatorName {
+	factories := r.Factories()
+	names := make([]ValidatorName, len(factories))
+	for i, factory := range factories {
+		names[i] = factory.Name()
+	}
+
+	return names
+}
+
+// Factories returns registered factories sorted by names
+func (r *Registry) Factories() []Factory {
+	r.mu.RLock()
+	factories := make([]Factory, 0, len(r.factories))
+	for _, factory := range r.factories {
+		factories = append(factories, factory)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(factories, func(l, r int) bool {
+		return factories[l].Name() < factories[r].Name()
+	})
+
+	return factories
+}
+
+// Params returns default params of validator filled by decode and checked by ParamsValidator.
+// decode can be nil to get default params.
+func (r *Registry) Params(name ValidatorName, decode ParamsDecoder) (interface{}, error) {
+	factory, ok := r.Get(name)
+	if !ok {
+		return nil, &UnknownFactoryError{Name: name}
+	}
+
+	params := factory.Params()
+	if decode != nil {
+		if err := decode(params); err != nil {
+			return nil, &ParamsError{Validator: name, Reason: err.Error()}
+		}
+	}
+
+	return params, validateParams(name, params)
+}
+
+// New instantiates validator by name with params, params are default if nil
+func (r *Registry) New(name ValidatorName, params interface{}) (Validator, error) {
+	factory, ok := r.Get(name)
+	if !ok {
+		return nil, &UnknownFactoryError{Name: name}
+	}
+
+	if err := validateParams(name, params); err != nil {
+		return nil, err
+	}
+
+	return factory.New(params)
+}
+
+func validateParams(name ValidatorName, params interface{}) error {
+	paramsValidator, ok := params.(ParamsValidator)
+	if !ok {
+		return nil
+	}
+
+	return withValidatorName(name, paramsValidator.Validate())
+}
+
+// withValidatorName sets ParamsError.Validator, if it is empty
+func withValidatorName(name ValidatorName, err error) error {
+	if paramsErr, ok := err.(*ParamsError); ok && paramsErr.Validator == "" {
+		paramsErr.Validator = name
+	}
+
+	return err
+}
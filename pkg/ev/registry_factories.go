@@ -0,0 +1,261 @@
+package ev
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/emirpasic/gods/sets/hashset"
+	"github.com/prodadidb/go-email-validator/pkg/ev/contains"
+	"github.com/prodadidb/go-email-validator/pkg/ev/disposable"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsuggest"
+	"github.com/prodadidb/go-email-validator/pkg/ev/free"
+	"github.com/prodadidb/go-email-validator/pkg/ev/role"
+)
+
+// NoParams are params of validators without parameters
+type NoParams struct{}
+
+// SyntaxParams are params of SyntaxValidatorName factory
+type SyntaxParams struct {
+	// Pattern is regular expression, NewSyntaxValidator is used if it is empty
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+}
+
+// Validate checks Pattern
+func (s SyntaxParams) Validate() error {
+	if _, err := regexp.Compile(s.Pattern); err != nil {
+		return &ParamsError{Field: "pattern", Reason: err.Error()}
+	}
+
+	return nil
+}
+
+// GravatarParams are params of GravatarValidatorName factory
+type GravatarParams struct {
+	// URL is GravatarURL by default
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// SMTPParams are params of SMTPValidatorName factory
+type SMTPParams struct {
+	SMTP *SMTPConfig `json:"smtp,omitempty" yaml:"smtp,omitempty"`
+}
+
+// Validate checks SMTP
+func (s SMTPParams) Validate() error {
+	if s.SMTP == nil {
+		return nil
+	}
+
+	return s.SMTP.validate()
+}
+
+// SubaddressParams are params of SubaddressValidatorName factory
+type SubaddressParams struct {
+	// Severity is error, warning or info, SeverityWarning by default
+	Severity Severity `json:"severity,omitempty" yaml:"severity,omitempty"`
+	// Separators are used for domains without rules of providers, DefaultSubaddressSeparators by default
+	Separators []string `json:"separators,omitempty" yaml:"separators,omitempty"`
+}
+
+// Validate checks Severity and Separators
+func (s SubaddressParams) Validate() error {
+	if s.Severity != "" && !s.Severity.Valid() {
+		return &ParamsError{Field: "severity", Reason: fmt.Sprintf("unknown severity %q", s.Severity)}
+	}
+	for _, separator := range s.Separators {
+		if separator == "" {
+			return &ParamsError{Field: "separators", Reason: "empty separator"}
+		}
+	}
+
+	return nil
+}
+
+// SuggestionParams are params of SuggestionValidatorName factory
+type SuggestionParams struct {
+	// Severity is error, warning or info, SeverityWarning by default
+	Severity Severity `json:"severity,omitempty" yaml:"severity,omitempty"`
+	// Domains are popular domains, evsuggest.DefaultPopularDomains() by default
+	Domains []string `json:"domains,omitempty" yaml:"domains,omitempty"`
+	// MinConfidence is evsuggest.DefaultMinConfidence by default
+	MinConfidence float64 `json:"minConfidence,omitempty" yaml:"minConfidence,omitempty"`
+}
+
+// Validate checks Severity and MinConfidence
+func (s SuggestionParams) Validate() error {
+	if s.Severity != "" && !s.Severity.Valid() {
+		return &ParamsError{Field: "severity", Reason: fmt.Sprintf("unknown severity %q", s.Severity)}
+	}
+	if s.MinConfidence < 0 || s.MinConfidence > 1 {
+		return &ParamsError{Field: "minConfidence", Reason: "should be from 0 to 1"}
+	}
+
+	return nil
+}
+
+// ListParams are params of contains-based validators
+type ListParams struct {
+	List *ListConfig `json:"list,omitempty" yaml:"list,omitempty"`
+	// required is false for validators with built-in lists
+	required bool
+}
+
+// Validate checks List
+func (l ListParams) Validate() error {
+	switch {
+	case l.List == nil && l.required:
+		return &ParamsError{Field: "list", Reason: "is required"}
+	case l.List != nil && len(l.List.Items) == 0 && l.List.File == "":
+		return &ParamsError{Field: "list", Reason: "items or file is required"}
+	}
+
+	return nil
+}
+
+func (l *ListParams) setDir(dir string) {
+	if l.List != nil {
+		l.List.dir = dir
+	}
+}
+
+func (l ListParams) values() ([]string, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	if l.List == nil {
+		return nil, &ParamsError{Field: "list", Reason: "is required"}
+	}
+
+	values, err := l.List.values()
+	if err != nil {
+		return nil, &ParamsError{Field: "list.file", Reason: err.Error()}
+	}
+
+	return values, nil
+}
+
+func (l ListParams) set(defaultSet func() contains.InSet) (contains.InSet, error) {
+	if l.List == nil && defaultSet != nil {
+		return defaultSet(), nil
+	}
+
+	values, err := l.values()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(values))
+	for i, value := range values {
+		items[i] = value
+	}
+
+	return contains.NewSet(hashset.New(items...)), nil
+}
+
+func newListFactory(name ValidatorName, description string, defaultSet func() contains.InSet, create func(contains.InSet) Validator) Factory {
+	return NewFactory(name, description, ListParams{required: defaultSet == nil}, func(params ListParams) (Validator, error) {
+		set, err := params.set(defaultSet)
+		if err != nil {
+			return nil, err
+		}
+
+		return create(set), nil
+	})
+}
+
+// BuiltinFactories returns factories of validators of the package
+func BuiltinFactories() []Factory {
+	return []Factory{
+		NewFactory(SyntaxValidatorName, "checks syntax of email by regular expression", SyntaxParams{},
+			func(params SyntaxParams) (Validator, error) {
+				if params.Pattern == "" {
+					return NewSyntaxValidator(), nil
+				}
+
+				emailRegex, err := regexp.Compile(params.Pattern)
+				if err != nil {
+					return nil, &ParamsError{Field: "pattern", Reason: err.Error()}
+				}
+
+				return NewSyntaxRegexValidator(emailRegex), nil
+			},
+		),
+		NewFactory(MXValidatorName, "looks up MX records of domain", NoParams{},
+			func(NoParams) (Validator, error) {
+				return DefaultNewMXValidator(), nil
+			},
+		),
+		NewFactory(SMTPValidatorName, "checks mailbox by SMTP, errors of random RCPT are warnings", SMTPParams{},
+			func(params SMTPParams) (Validator, error) {
+				var smtpConfig SMTPConfig
+				if params.SMTP != nil {
+					smtpConfig = *params.SMTP
+				}
+
+				return GetDefaultSMTPValidator(evsmtp.CheckerDTO{
+					Options:        smtpConfig.Options(),
+					CircuitBreaker: smtpConfig.NewCircuitBreaker(),
+				}), nil
+			},
+		),
+		NewFactory(GravatarValidatorName, "checks existence of gravatar", GravatarParams{},
+			func(params GravatarParams) (Validator, error) {
+				if params.URL == "" {
+					return NewGravatarValidator(), nil
+				}
+
+				return NewGravatarValidatorWithURL(params.URL), nil
+			},
+		),
+		newListFactory(DisposableValidatorName, "checks domain in list of disposable domains",
+			func() contains.InSet {
+				return contains.NewFunc(disposable.MailChecker)
+			},
+			NewDisposableValidator,
+		),
+		newListFactory(RoleValidatorName, "checks username in list of roles", role.NewRBEASetRole, NewRoleValidator),
+		newListFactory(FreeValidatorName, "checks domain in list of free email providers", free.NewWillWhiteSetFree, NewFreeValidator),
+		newListFactory(BlackListEmailsValidatorName, "checks email in black list", nil, NewBlackListEmailsValidator),
+		newListFactory(BlackListDomainsValidatorName, "checks domain in black list", nil, NewBlackListValidator),
+		newListFactory(WhiteListDomainValidatorName, "checks domain in white list", nil, NewWhiteListValidator),
+		NewFactory(SubaddressValidatorName, "detects subaddresses with tags, e.g. john+promo@gmail.com", SubaddressParams{},
+			func(params SubaddressParams) (Validator, error) {
+				return NewSubaddressValidator(SubaddressValidatorDTO{
+					Separators: params.Separators,
+					Severity:   params.Severity,
+				}), nil
+			},
+		),
+		NewFactory(SuggestionValidatorName, "suggests popular or free domains for misspelled domains, e.g. gmail.com for gmial.com",
+			SuggestionParams{},
+			func(params SuggestionParams) (Validator, error) {
+				return NewSuggestionValidator(SuggestionValidatorDTO{
+					Suggester: evsuggest.NewSuggester(evsuggest.SuggesterDTO{
+						Domains:       params.Domains,
+						MinConfidence: params.MinConfidence,
+					}),
+					Severity: params.Severity,
+				}), nil
+			},
+		),
+		NewFactory(BanWordsUsernameValidatorName, "checks ban words in username", ListParams{required: true},
+			func(params ListParams) (Validator, error) {
+				values, err := params.values()
+				if err != nil {
+					return nil, err
+				}
+
+				return NewBanWordsUsername(contains.NewInStringsFromArray(values)), nil
+			},
+		),
+	}
+}
+
+var defaultRegistry = NewRegistry(BuiltinFactories()...)
+
+// DefaultRegistry returns Registry with BuiltinFactories, packages can register own factories in it
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
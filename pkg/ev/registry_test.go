@@ -0,0 +1,173 @@
+package ev_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/stretchr/testify/require"
+)
+
+const lengthValidatorName ev.ValidatorName = "Length"
+
+type lengthParams struct {
+	Max int `json:"max" yaml:"max"`
+}
+
+func (l lengthParams) Validate() error {
+	if l.Max <= 0 {
+		return &ev.ParamsError{Field: "max", Reason: "should be positive"}
+	}
+
+	return nil
+}
+
+type lengthValidator struct {
+	ev.AValidatorWithoutDeps
+	max int
+}
+
+func (l lengthValidator) Validate(input ev.Input, _ ...ev.ValidationResult) ev.ValidationResult {
+	return ev.NewResult(len(input.Email().String()) <= l.max, nil, nil, lengthValidatorName)
+}
+
+var lengthFactory = ev.NewFactory(lengthValidatorName, "checks length of email", lengthParams{Max: 254},
+	func(params lengthParams) (ev.Validator, error) {
+		return lengthValidator{max: params.Max}, nil
+	},
+)
+
+func TestRegistry_Register(t *testing.T) {
+	registry := ev.NewRegistry(lengthFactory)
+
+	err := registry.Register(lengthFactory)
+	var duplicateErr *ev.DuplicateFactoryError
+	require.True(t, errors.As(err, &duplicateErr))
+	require.Panics(t, func() {
+		registry.MustRegister(lengthFactory)
+	})
+
+	factory, ok := registry.Get(lengthValidatorName)
+	require.True(t, ok)
+	require.Equal(t, "checks length of email", factory.Description())
+	require.Equal(t, &lengthParams{Max: 254}, factory.Params())
+}
+
+func TestRegistry_Names(t *testing.T) {
+	registry := ev.NewRegistry(ev.BuiltinFactories()...)
+	registry.MustRegister(lengthFactory)
+
+	names := registry.Names()
+	require.Contains(t, names, lengthValidatorName)
+	require.Contains(t, names, ev.SMTPValidatorName)
+	require.IsIncreasing(t, names)
+	require.Len(t, registry.Factories(), len(names))
+}
+
+func TestRegistry_New(t *testing.T) {
+	registry := ev.NewRegistry(lengthFactory)
+	email := ev.NewInput(evmail.FromString("user@domain.com"))
+
+	tests := []struct {
+		name      string
+		params    interface{}
+		wantValid bool
+		wantErr   error
+	}{
+		{name: "default", params: nil, wantValid: true},
+		{name: "value", params: lengthParams{Max: 5}, wantValid: false},
+		{name: "pointer", params: &lengthParams{Max: 100}, wantValid: true},
+		{name: "invalid", params: lengthParams{}, wantErr: &ev.ParamsError{Validator: lengthValidatorName, Field: "max", Reason: "should be positive"}},
+		{name: "unexpected type", params: 5, wantErr: &ev.ParamsError{Validator: lengthValidatorName, Reason: "unexpected params int, ev_test.lengthParams is expected"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := registry.New(lengthValidatorName, tt.params)
+			if tt.wantErr != nil {
+				require.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantValid, validator.Validate(email).IsValid())
+		})
+	}
+
+	_, err := registry.New(ev.SMTPValidatorName, nil)
+	require.Equal(t, &ev.UnknownFactoryError{Name: ev.SMTPValidatorName}, err)
+}
+
+func TestRegistry_Params(t *testing.T) {
+	registry := ev.NewRegistry(lengthFactory)
+
+	params, err := registry.Params(lengthValidatorName, func(params interface{}) error {
+		return json.Unmarshal([]byte(`{"max": 10}`), params)
+	})
+	require.NoError(t, err)
+	require.Equal(t, &lengthParams{Max: 10}, params)
+
+	_, err = registry.Params(lengthValidatorName, func(params interface{}) error {
+		return json.Unmarshal([]byte(`{"max": -1}`), params)
+	})
+	require.EqualError(t, err, "ParamsError: Length: max: should be positive")
+}
+
+func TestBuiltinFactories(t *testing.T) {
+	requireList := map[ev.ValidatorName]bool{
+		ev.BlackListEmailsValidatorName:  true,
+		ev.BlackListDomainsValidatorName: true,
+		ev.WhiteListDomainValidatorName:  true,
+		ev.BanWordsUsernameValidatorName: true,
+	}
+
+	for _, factory := range ev.BuiltinFactories() {
+		t.Run(factory.Name().String(), func(t *testing.T) {
+			_, err := ev.DefaultRegistry().New(factory.Name(), nil)
+			if requireList[factory.Name()] {
+				require.EqualError(t, err, "ParamsError: "+factory.Name().String()+": list: is required")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDepBuilder_SetByName(t *testing.T) {
+	builder := ev.NewDepBuilder(ev.ValidatorMap{}).SetRegistry(ev.NewRegistry(lengthFactory))
+
+	require.NoError(t, builder.SetByName(lengthValidatorName, lengthParams{Max: 5}))
+	require.False(t, builder.Build().Validate(ev.NewInput(evmail.FromString("user@domain.com"))).IsValid())
+
+	err := builder.SetByName(ev.SyntaxValidatorName, nil)
+	var unknownErr *ev.UnknownFactoryError
+	require.True(t, errors.As(err, &unknownErr))
+
+	builder.Registry = nil
+	require.NoError(t, builder.SetByName(ev.BlackListDomainsValidatorName, ev.ListParams{List: &ev.ListConfig{Items: []string{"domain.com"}}}))
+	require.False(t, builder.Get(ev.BlackListDomainsValidatorName).Validate(ev.NewInput(evmail.FromString("user@domain.com"))).IsValid())
+}
+
+func TestRegistry_ParseConfig(t *testing.T) {
+	registry := ev.NewRegistry(ev.BuiltinFactories()...)
+	registry.MustRegister(lengthFactory)
+
+	config, err := registry.ParseConfig([]byte(`
+validators:
+  - name: syntaxValidator
+  - name: Length
+    max: 10
+`))
+	require.NoError(t, err)
+
+	validator, err := config.Build()
+	require.NoError(t, err)
+	require.False(t, validator.Validate(ev.NewInput(evmail.FromString("long.user@domain.com"))).IsValid())
+
+	_, err = registry.ParseConfig([]byte(`{"validators": [{"name": "Length", "max": 0}]}`))
+	require.EqualError(t, err, "ConfigError: validators.Length.max: should be positive")
+
+	_, err = ev.ParseConfig([]byte(`{"validators": [{"name": "Length"}]}`))
+	require.EqualError(t, err, `ConfigError: validators[0].name: unknown validator "Length"`)
+}
@@ -0,0 +1,498 @@
+package ev
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/textproto"
+	"reflect"
+	"strings"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+)
+
+func init() {
+	RegisterJSONError(SyntaxError{})
+	RegisterJSONError(EmptyMXsError{})
+	RegisterJSONError(DisposableError{})
+	RegisterJSONError(FreeError{})
+	RegisterJSONError(RoleError{})
+	RegisterJSONError(BanWordsUsernameError{})
+	RegisterJSONError(BlackListDomainsError{})
+	RegisterJSONError(BlackListEmailsError{})
+	RegisterJSONError(WhiteListError{})
+	RegisterJSONError(GravatarError{})
+	RegisterJSONError(NotApplicableError{})
+	RegisterJSONError(&DepsError{})
+	RegisterJSONError(&PanicError{})
+	RegisterJSONError(&TimeoutError{})
+	RegisterJSONError(&SkippedError{})
+	RegisterJSONError(&RetryError{})
+	RegisterJSONError(&RateLimitedError{})
+	RegisterJSONError(&SubaddressError{})
+	RegisterJSONError(&SuggestionError{})
+	RegisterJSONError(&evsmtp.DefaultError{})
+	RegisterJSONError(&textproto.Error{})
+}
+
+var jsonErrorTypes = make(map[string]reflect.Type)
+
+// RegisterJSONError registers type of err for unmarshalling of results from JSON.
+// Errors of unregistered types are unmarshalled as JSONError.
+func RegisterJSONError(err error) {
+	jsonErrorTypes[jsonErrorType(err)] = reflect.TypeOf(err)
+}
+
+// jsonErrorType returns name of type of err with package, e.g. ev.RoleError
+func jsonErrorType(err error) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", err), "*")
+}
+
+// JSONError is error unmarshalled from JSON, which type is not registered by RegisterJSONError
+type JSONError struct {
+	TypeVal     string
+	MessageVal  string
+	CodeVal     string
+	CategoryVal string
+	DetailsVal  map[string]interface{}
+}
+
+func (j *JSONError) Error() string {
+	return j.MessageVal
+}
+
+// Code returns code of original error
+func (j *JSONError) Code() string {
+	return j.CodeVal
+}
+
+// Category returns category of original error
+func (j *JSONError) Category() string {
+	return j.CategoryVal
+}
+
+// Details returns details of original error
+func (j *JSONError) Details() map[string]interface{} {
+	return j.DetailsVal
+}
+
+// errorJSON is JSON representation of errors of results
+type errorJSON struct {
+	// Type is type of error with package, e.g. ev.RoleError
+	Type     string                 `json:"type"`
+	Code     string                 `json:"code"`
+	Category string                 `json:"category"`
+	Message  string                 `json:"message"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+	// Value is JSON of error of registered type, it is omitted for empty structures
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func marshalErrors(errs []error) ([]errorJSON, error) {
+	if len(errs) == 0 {
+		return nil, nil
+	}
+
+	data := make([]errorJSON, len(errs))
+	for i, err := range errs {
+		if jsonErr, ok := err.(*JSONError); ok {
+			data[i] = errorJSON{
+				Type:     jsonErr.TypeVal,
+				Code:     jsonErr.CodeVal,
+				Category: jsonErr.CategoryVal,
+				Message:  jsonErr.MessageVal,
+				Details:  jsonErr.DetailsVal,
+			}
+			continue
+		}
+
+		coded := ToCodedError(err)
+		data[i] = errorJSON{
+			Type:     jsonErrorType(err),
+			Code:     coded.Code(),
+			Category: coded.Category(),
+			Message:  err.Error(),
+			Details:  coded.Details(),
+		}
+		if _, ok := jsonErrorTypes[data[i].Type]; !ok {
+			continue
+		}
+
+		value, err := json.Marshal(err)
+		if err != nil {
+			return nil, err
+		}
+		if string(value) != "{}" {
+			data[i].Value = value
+		}
+	}
+
+	return data, nil
+}
+
+func unmarshalErrors(data []errorJSON) ([]error, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	errs := make([]error, len(data))
+	for i, errData := range data {
+		typ, ok := jsonErrorTypes[errData.Type]
+		if !ok {
+			errs[i] = &JSONError{
+				TypeVal:     errData.Type,
+				MessageVal:  errData.Message,
+				CodeVal:     errData.Code,
+				CategoryVal: errData.Category,
+				DetailsVal:  errData.Details,
+			}
+			continue
+		}
+
+		value := errData.Value
+		if len(value) == 0 {
+			value = json.RawMessage("{}")
+		}
+
+		ptr := reflect.New(typ)
+		if err := json.Unmarshal(value, ptr.Interface()); err != nil {
+			return nil, err
+		}
+		errs[i] = ptr.Elem().Interface().(error)
+	}
+
+	return errs, nil
+}
+
+// panicErrorJSON is JSON representation of PanicError
+type panicErrorJSON struct {
+	Validator ValidatorName `json:"validator"`
+	Value     string        `json:"value"`
+}
+
+// MarshalJSON implements json.Marshaler, it keeps validator and text of panic value, stack is not marshaled
+func (p *PanicError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(panicErrorJSON{Validator: p.Validator, Value: fmt.Sprint(p.Value)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, Value is unmarshalled as text
+func (p *PanicError) UnmarshalJSON(b []byte) error {
+	var data panicErrorJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	p.Validator = data.Validator
+	p.Value = data.Value
+
+	return nil
+}
+
+// resultJSON is JSON representation of results
+type resultJSON struct {
+	Validator ValidatorName `json:"validator"`
+	Valid     bool          `json:"valid"`
+	Outcome   Outcome       `json:"outcome"`
+	Errors    []errorJSON   `json:"errors,omitempty"`
+	Warnings  []errorJSON   `json:"warnings,omitempty"`
+	// MX is set for MXValidatorName
+	MX []mxJSON `json:"mx,omitempty"`
+	// URL is set for GravatarValidatorName
+	URL string `json:"url,omitempty"`
+	// Mailbox and Tag are set for subaddresses of SubaddressValidatorName
+	Mailbox string `json:"mailbox,omitempty"`
+	Tag     string `json:"tag,omitempty"`
+	// Suggestion and Confidence are set for misspelled domains of SuggestionValidatorName
+	Suggestion string  `json:"suggestion,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"`
+	// Results are set for DepValidatorName
+	Results map[ValidatorName]json.RawMessage `json:"results,omitempty"`
+	Trace   *Trace                            `json:"trace,omitempty"`
+}
+
+type mxJSON struct {
+	Host string `json:"host"`
+	Pref uint16 `json:"pref"`
+}
+
+func newResultJSON(a *AValidationResult) (resultJSON, error) {
+	var data resultJSON
+	if a == nil {
+		return data, nil
+	}
+
+	var err error
+	data.Validator = a.NameVal
+	data.Valid = a.IsValidVal
+	data.Outcome = a.Outcome()
+	if data.Errors, err = marshalErrors(a.ErrorsVal); err != nil {
+		return data, err
+	}
+	data.Warnings, err = marshalErrors(a.WarningsVal)
+
+	return data, err
+}
+
+func (r resultJSON) aValidationResult() (*AValidationResult, error) {
+	errs, err := unmarshalErrors(r.Errors)
+	if err != nil {
+		return nil, err
+	}
+	warnings, err := unmarshalErrors(r.Warnings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AValidationResult{
+		IsValidVal:  r.Valid,
+		ErrorsVal:   errs,
+		WarningsVal: warnings,
+		NameVal:     r.Validator,
+		UnknownVal:  r.Outcome == OutcomeUnknown,
+	}, nil
+}
+
+// MarshalJSON implements json.Marshaler, errors keep their types, codes and messages
+func (a *AValidationResult) MarshalJSON() ([]byte, error) {
+	data, err := newResultJSON(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *AValidationResult) UnmarshalJSON(b []byte) error {
+	var data resultJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	result, err := data.aValidationResult()
+	if err != nil {
+		return err
+	}
+	*a = *result
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (v mxValidationResult) MarshalJSON() ([]byte, error) {
+	data, err := newResultJSON(v.AValidationResult)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mx := range v.mx {
+		if mx != nil {
+			data.MX = append(data.MX, mxJSON{Host: mx.Host, Pref: mx.Pref})
+		}
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (v *mxValidationResult) UnmarshalJSON(b []byte) error {
+	var data resultJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	result, err := data.aValidationResult()
+	if err != nil {
+		return err
+	}
+
+	v.AValidationResult = result
+	v.mx = nil
+	for _, mx := range data.MX {
+		v.mx = append(v.mx, &net.MX{Host: mx.Host, Pref: mx.Pref})
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (v gravatarValidationResult) MarshalJSON() ([]byte, error) {
+	data, err := newResultJSON(v.AValidationResult)
+	if err != nil {
+		return nil, err
+	}
+	data.URL = v.url
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (v *gravatarValidationResult) UnmarshalJSON(b []byte) error {
+	var data resultJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	result, err := data.aValidationResult()
+	if err != nil {
+		return err
+	}
+	v.AValidationResult = result
+	v.url = data.URL
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (s subaddressValidationResult) MarshalJSON() ([]byte, error) {
+	data, err := newResultJSON(s.AValidationResult)
+	if err != nil {
+		return nil, err
+	}
+	if s.mailbox != nil {
+		data.Mailbox = s.mailbox.String()
+		data.Tag = s.tag
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *subaddressValidationResult) UnmarshalJSON(b []byte) error {
+	var data resultJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	result, err := data.aValidationResult()
+	if err != nil {
+		return err
+	}
+	s.AValidationResult = result
+	s.mailbox = nil
+	s.tag = data.Tag
+	if data.Mailbox != "" {
+		s.mailbox = evmail.FromString(data.Mailbox)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (s suggestionValidationResult) MarshalJSON() ([]byte, error) {
+	data, err := newResultJSON(s.AValidationResult)
+	if err != nil {
+		return nil, err
+	}
+	data.Suggestion = s.suggestion
+	data.Confidence = s.confidence
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *suggestionValidationResult) UnmarshalJSON(b []byte) error {
+	var data resultJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	result, err := data.aValidationResult()
+	if err != nil {
+		return err
+	}
+	s.AValidationResult = result
+	s.suggestion = data.Suggestion
+	s.confidence = data.Confidence
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, nested results are marshaled by names of validators
+func (d depValidationResult) MarshalJSON() ([]byte, error) {
+	data := resultJSON{
+		Validator: DepValidatorName,
+		Valid:     d.isValid,
+		Outcome:   d.Outcome(),
+		Results:   make(map[ValidatorName]json.RawMessage, len(d.results)),
+	}
+	if !d.trace.Start.IsZero() || len(d.trace.Validators) > 0 {
+		data.Trace = &d.trace
+	}
+
+	for name, result := range d.results {
+		resultData, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		data.Results[name] = resultData
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, nested results are unmarshalled by UnmarshalResultJSON
+func (d *depValidationResult) UnmarshalJSON(b []byte) error {
+	var data resultJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	d.isValid = data.Valid
+	d.trace = Trace{}
+	if data.Trace != nil {
+		d.trace = *data.Trace
+	}
+	d.results = make(DepResult, len(data.Results))
+	for name, resultData := range data.Results {
+		result, err := UnmarshalResultJSON(resultData)
+		if err != nil {
+			return fmt.Errorf("result of %s: %w", name, err)
+		}
+		d.results[name] = result
+	}
+
+	return nil
+}
+
+// UnmarshalResultJSON unmarshals result from JSON by name of its validator,
+// e.g. results of DepValidatorName are DepValidationResult and results of MXValidatorName are MXValidationResult
+func UnmarshalResultJSON(data []byte) (ValidationResult, error) {
+	var head struct {
+		Validator ValidatorName `json:"validator"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+
+	switch head.Validator {
+	case DepValidatorName:
+		result := depValidationResult{}
+		err := json.Unmarshal(data, &result)
+		return result, err
+	case MXValidatorName:
+		result := mxValidationResult{}
+		err := json.Unmarshal(data, &result)
+		return result, err
+	case GravatarValidatorName:
+		result := gravatarValidationResult{}
+		err := json.Unmarshal(data, &result)
+		return result, err
+	case SubaddressValidatorName:
+		result := subaddressValidationResult{}
+		err := json.Unmarshal(data, &result)
+		return result, err
+	case SuggestionValidatorName:
+		result := suggestionValidationResult{}
+		err := json.Unmarshal(data, &result)
+		return result, err
+	}
+
+	result := &AValidationResult{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
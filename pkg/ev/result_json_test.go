@@ -0,0 +1,188 @@
+package ev_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAValidationResult_JSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		result ev.ValidationResult
+	}{
+		{
+			name:   "valid",
+			result: ev.NewValidResult(ev.SyntaxValidatorName),
+		},
+		{
+			name:   "invalid",
+			result: ev.NewResult(false, utils.Errs(ev.RoleError{}), utils.Errs(ev.FreeError{}), ev.RoleValidatorName),
+		},
+		{
+			name: "unknown",
+			result: ev.NewResultWithOutcome(ev.OutcomeUnknown, utils.Errs(
+				evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 451, Msg: "greylisted"}),
+				ev.NewTimeoutError(ev.SMTPValidatorName),
+			), utils.Errs(&ev.RetryError{Attempts: []ev.RetryAttempt{{Outcome: ev.OutcomeUnknown, Delay: time.Second}}}), ev.SMTPValidatorName),
+		},
+		{
+			name:   "skipped",
+			result: ev.NewSkippedResult(ev.RoleValidatorName, ev.SkipReasonFirstError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.result)
+			require.NoError(t, err)
+
+			got, err := ev.UnmarshalResultJSON(data)
+			require.NoError(t, err)
+			require.Equal(t, tt.result, got)
+			require.Equal(t, tt.result.Outcome(), got.Outcome())
+		})
+	}
+}
+
+func TestAValidationResult_MarshalJSON(t *testing.T) {
+	result := ev.NewResult(false, utils.Errs(
+		evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "unknown"}),
+	), nil, ev.SMTPValidatorName)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"validator": "SMTPValidator",
+		"valid": false,
+		"outcome": "invalid",
+		"errors": [{
+			"type": "evsmtp.DefaultError",
+			"code": "smtp_rcpt",
+			"category": "mailbox",
+			"message": "550 \"unknown\" happened on stage \"5\"",
+			"details": {"stage": "rcpts", "replyCode": 550, "message": "unknown"},
+			"value": {"stage": "rcpts", "type": "textproto.Error", "message": "unknown", "replyCode": 550}
+		}]
+	}`, string(data))
+}
+
+func TestPanicError_JSON(t *testing.T) {
+	panicErr := ev.NewPanicError(ev.SMTPValidatorName, errors.New("boom"), []byte("goroutine 1 [running]:"))
+	result := ev.NewResultWithOutcome(ev.OutcomeUnknown, utils.Errs(panicErr), nil, ev.SMTPValidatorName)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "goroutine")
+	require.NotContains(t, string(data), "stack")
+	require.JSONEq(t, `{
+		"validator": "SMTPValidator",
+		"valid": false,
+		"outcome": "unknown",
+		"errors": [{
+			"type": "ev.PanicError",
+			"code": "panic",
+			"category": "transient",
+			"message": "PanicError: validator \"SMTPValidator\" panicked: boom",
+			"details": {"validator": "SMTPValidator", "value": "boom"},
+			"value": {"validator": "SMTPValidator", "value": "boom"}
+		}]
+	}`, string(data))
+
+	got, err := ev.UnmarshalResultJSON(data)
+	require.NoError(t, err)
+	require.Equal(t, []error{&ev.PanicError{Validator: ev.SMTPValidatorName, Value: "boom"}}, got.Errors())
+}
+
+func TestAValidationResult_JSON_UnregisteredError(t *testing.T) {
+	result := ev.NewResult(false, utils.Errs(errors.New("custom")), nil, ev.OtherValidator)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	got, err := ev.UnmarshalResultJSON(data)
+	require.NoError(t, err)
+	require.Equal(t, []error{&ev.JSONError{
+		TypeVal:     "errors.errorString",
+		MessageVal:  "custom",
+		CodeVal:     ev.UnknownCode,
+		CategoryVal: ev.TransientCategory,
+	}}, got.Errors())
+
+	// JSONError keeps its type
+	again, err := json.Marshal(got)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(again))
+}
+
+func TestDepValidationResult_JSON(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := ev.NewDepValidatorResultWithTrace(false, ev.DepResult{
+		ev.SyntaxValidatorName: ev.NewValidResult(ev.SyntaxValidatorName),
+		ev.MXValidatorName: ev.NewMXValidationResult(
+			evsmtp.MXs{&net.MX{Host: "mx.domain.com.", Pref: 10}},
+			ev.NewValidResult(ev.MXValidatorName).(*ev.AValidationResult),
+		),
+		ev.SMTPValidatorName: ev.NewResult(false, utils.Errs(
+			evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "unknown"}),
+			evsmtp.NewError(evsmtp.HelloStage, context.Canceled),
+		), nil, ev.SMTPValidatorName),
+		ev.GravatarValidatorName: ev.NewGravatarValidationResult(
+			"https://www.gravatar.com/avatar/hash",
+			ev.NewValidResult(ev.GravatarValidatorName).(*ev.AValidationResult),
+		),
+		ev.SubaddressValidatorName: ev.NewSubaddressValidationResult(
+			evmail.FromString("john@gmail.com"),
+			"promo",
+			ev.NewResult(true, nil, utils.Errs(&ev.SubaddressError{Mailbox: "john@gmail.com", Tag: "promo", Provider: "gmail"}),
+				ev.SubaddressValidatorName).(*ev.AValidationResult),
+		),
+		ev.SuggestionValidatorName: ev.NewSuggestionValidationResult(
+			"gmail.com",
+			0.9,
+			ev.NewResult(true, nil, utils.Errs(&ev.SuggestionError{Domain: "gmial.com", Suggestion: "gmail.com", Confidence: 0.9}),
+				ev.SuggestionValidatorName).(*ev.AValidationResult),
+		),
+	}, ev.Trace{
+		Start:    start,
+		Duration: time.Second,
+		Validators: []ev.ValidatorTrace{{
+			Validator: ev.SMTPValidatorName,
+			Start:     start,
+			Duration:  time.Second,
+			Status:    ev.TraceInvalid,
+			Stages:    []evsmtp.StageTiming{{Stage: evsmtp.RCPTsStage, Name: "rcpts", Start: start, Duration: time.Second}},
+		}},
+	})
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	got, err := ev.UnmarshalResultJSON(data)
+	require.NoError(t, err)
+	require.Equal(t, result, got)
+
+	depResult := got.(ev.DepValidationResult)
+	require.Equal(t, "mx.domain.com.", depResult.GetResults()[ev.MXValidatorName].(ev.MXValidationResult).MX()[0].Host)
+	require.Equal(t, "https://www.gravatar.com/avatar/hash", depResult.GetResults()[ev.GravatarValidatorName].(ev.GravatarValidationResult).URL())
+	require.True(t, evsmtp.IsTransient(depResult.GetResults()[ev.SMTPValidatorName].Errors()[1]))
+	require.Equal(t, "promo", depResult.GetResults()[ev.SubaddressValidatorName].(ev.SubaddressValidationResult).Tag())
+	require.Equal(t, "gmail.com", depResult.GetResults()[ev.SuggestionValidatorName].(ev.SuggestionValidationResult).Suggestion())
+}
+
+func TestUnmarshalResultJSON_Error(t *testing.T) {
+	_, err := ev.UnmarshalResultJSON([]byte(`{"validator": "depValidator", "results": {"syntaxValidator": {"outcome": "bad"}}}`))
+	require.Error(t, err)
+
+	_, err = ev.UnmarshalResultJSON([]byte(`[]`))
+	require.Error(t, err)
+}
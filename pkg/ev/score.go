@@ -0,0 +1,377 @@
+package ev
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"gopkg.in/yaml.v3"
+)
+
+// ScoreOp is operation of ScoreRule with score
+type ScoreOp string
+
+// Operations of ScoreRule
+const (
+	// ScoreAdd adds weight to score, it is default operation
+	ScoreAdd ScoreOp = "add"
+	// ScoreSet sets score to weight
+	ScoreSet ScoreOp = "set"
+	// ScoreAtLeast raises score to weight
+	ScoreAtLeast ScoreOp = "atLeast"
+	// ScoreAtMost lowers score to weight
+	ScoreAtMost ScoreOp = "atMost"
+)
+
+// ScoreCondition matches results of validation and email.
+// Errors, Stages, Code, Contains and NotContains are checked against the same error or warning of result.
+type ScoreCondition struct {
+	// Validator is name of validator, condition is false if there is no result of the validator
+	Validator ValidatorName `json:"validator,omitempty" yaml:"validator,omitempty"`
+	// Outcome matches Outcome.String of result: valid, invalid or unknown
+	Outcome string `json:"outcome,omitempty" yaml:"outcome,omitempty"`
+	// Errors match error by names of its types in the chain of wrapped errors, e.g. DepsError or SkippedError
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+	// Stages match evsmtp.Error by evsmtp.StageName
+	Stages []string `json:"stages,omitempty" yaml:"stages,omitempty"`
+	// Code matches SMTP reply code
+	Code int `json:"code,omitempty" yaml:"code,omitempty"`
+	// Contains matches error, which text contains any of substrings, case insensitive
+	Contains []string `json:"contains,omitempty" yaml:"contains,omitempty"`
+	// NotContains matches error, which text does not contain any of substrings, case insensitive
+	NotContains []string `json:"notContains,omitempty" yaml:"notContains,omitempty"`
+	// LocalPart matches local part of email by regular expression
+	LocalPart string `json:"localPart,omitempty" yaml:"localPart,omitempty"`
+	// Not inverts condition
+	Not bool `json:"not,omitempty" yaml:"not,omitempty"`
+}
+
+// ScoreRule changes score by Op and Weight if all facts from When are true.
+// Fact with "!" prefix is negated.
+type ScoreRule struct {
+	Name   string   `json:"name" yaml:"name"`
+	When   []string `json:"when,omitempty" yaml:"when,omitempty"`
+	Op     ScoreOp  `json:"op,omitempty" yaml:"op,omitempty"`
+	Weight float64  `json:"weight" yaml:"weight"`
+}
+
+// ScoreLevel is name of score, which is greater or equal to Min
+type ScoreLevel struct {
+	Name string  `json:"name" yaml:"name"`
+	Min  float64 `json:"min" yaml:"min"`
+}
+
+// ScoreRules is set of rules to calculate score of validation
+type ScoreRules struct {
+	// Base is initial score
+	Base float64 `json:"base,omitempty" yaml:"base,omitempty"`
+	// Scale divides score before clamping to 0..1, 1 is used for 0
+	Scale float64 `json:"scale,omitempty" yaml:"scale,omitempty"`
+	// Facts are named lists of conditions, fact is true if all conditions are true
+	Facts map[string][]ScoreCondition `json:"facts,omitempty" yaml:"facts,omitempty"`
+	// Rules are applied in order
+	Rules  []ScoreRule  `json:"rules" yaml:"rules"`
+	Levels []ScoreLevel `json:"levels,omitempty" yaml:"levels,omitempty"`
+}
+
+// RuleScore is result of ScoreRule
+type RuleScore struct {
+	Name    string  `json:"name"`
+	Matched bool    `json:"matched"`
+	Op      ScoreOp `json:"op"`
+	Weight  float64 `json:"weight"`
+	// Delta is change of raw score by the rule
+	Delta float64 `json:"delta"`
+}
+
+// ScoreResult is result of ScoreRules.Score
+type ScoreResult struct {
+	// Score is in range from 0 to 1
+	Score float64 `json:"score"`
+	// Raw is score before scaling and clamping
+	Raw   float64     `json:"raw"`
+	Level string      `json:"level,omitempty"`
+	Rules []RuleScore `json:"rules"`
+}
+
+// InvalidScoreRulesError is error of ScoreRules.Validate
+type InvalidScoreRulesError struct {
+	Reason string
+}
+
+func (i *InvalidScoreRulesError) Error() string {
+	return "InvalidScoreRulesError: " + i.Reason
+}
+
+func newInvalidScoreRulesError(format string, a ...interface{}) error {
+	return &InvalidScoreRulesError{Reason: fmt.Sprintf(format, a...)}
+}
+
+// ParseScoreRules parses and validates ScoreRules from YAML or JSON, unknown fields are forbidden
+func ParseScoreRules(data []byte) (ScoreRules, error) {
+	var rules ScoreRules
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&rules); err != nil {
+		return rules, err
+	}
+
+	return rules, rules.Validate()
+}
+
+// Validate checks operations, references to facts, outcomes, stages and regular expressions
+func (s ScoreRules) Validate() error {
+	if s.Scale < 0 {
+		return newInvalidScoreRulesError("scale %v is negative", s.Scale)
+	}
+
+	for name, conditions := range s.Facts {
+		for _, condition := range conditions {
+			if err := condition.validate(); err != nil {
+				return newInvalidScoreRulesError("fact %q: %v", name, err)
+			}
+		}
+	}
+
+	for _, rule := range s.Rules {
+		switch rule.Op {
+		case "", ScoreAdd, ScoreSet, ScoreAtLeast, ScoreAtMost:
+		default:
+			return newInvalidScoreRulesError("rule %q: unknown op %q", rule.Name, rule.Op)
+		}
+
+		for _, fact := range rule.When {
+			if _, ok := s.Facts[strings.TrimPrefix(fact, "!")]; !ok {
+				return newInvalidScoreRulesError("rule %q: unknown fact %q", rule.Name, fact)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c ScoreCondition) validate() error {
+	if c.Validator == "" && c.LocalPart == "" {
+		return errors.New("validator or localPart is required")
+	}
+
+	switch c.Outcome {
+	case "", OutcomeValid.String(), OutcomeInvalid.String(), OutcomeUnknown.String():
+	default:
+		return fmt.Errorf("unknown outcome %q", c.Outcome)
+	}
+
+	for _, stage := range c.Stages {
+		if _, ok := evsmtp.StageByName(stage); !ok {
+			return fmt.Errorf("unknown stage %q", stage)
+		}
+	}
+
+	if _, err := regexp.Compile(c.LocalPart); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Score calculates score of result. Nested results of DepValidationResult are used by names of validators.
+// email is used for ScoreCondition.LocalPart and can be nil.
+func (s ScoreRules) Score(email evmail.Address, result ValidationResult) ScoreResult {
+	results := DepResult{}
+	if depResult, ok := result.(DepValidationResult); ok {
+		results = depResult.GetResults()
+	} else if result != nil {
+		results[result.ValidatorName()] = result
+	}
+
+	facts := make(map[string]bool, len(s.Facts))
+	isFact := func(name string) bool {
+		value, ok := facts[name]
+		if !ok {
+			value = s.fact(name, email, results)
+			facts[name] = value
+		}
+
+		return value
+	}
+
+	score := ScoreResult{Raw: s.Base, Rules: make([]RuleScore, len(s.Rules))}
+	for i, rule := range s.Rules {
+		op := rule.Op
+		if op == "" {
+			op = ScoreAdd
+		}
+		ruleScore := RuleScore{Name: rule.Name, Matched: true, Op: op, Weight: rule.Weight}
+
+		for _, fact := range rule.When {
+			if negated := strings.HasPrefix(fact, "!"); isFact(strings.TrimPrefix(fact, "!")) == negated {
+				ruleScore.Matched = false
+				break
+			}
+		}
+
+		if ruleScore.Matched {
+			before := score.Raw
+			switch op {
+			case ScoreAdd:
+				score.Raw += rule.Weight
+			case ScoreSet:
+				score.Raw = rule.Weight
+			case ScoreAtLeast:
+				if score.Raw < rule.Weight {
+					score.Raw = rule.Weight
+				}
+			case ScoreAtMost:
+				if score.Raw > rule.Weight {
+					score.Raw = rule.Weight
+				}
+			}
+			ruleScore.Delta = score.Raw - before
+		}
+		score.Rules[i] = ruleScore
+	}
+
+	scale := s.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	score.Score = score.Raw / scale
+	switch {
+	case score.Score < 0:
+		score.Score = 0
+	case score.Score > 1:
+		score.Score = 1
+	}
+
+	levelMin := 0.0
+	for _, level := range s.Levels {
+		if score.Score >= level.Min && (score.Level == "" || level.Min > levelMin) {
+			score.Level, levelMin = level.Name, level.Min
+		}
+	}
+
+	return score
+}
+
+func (s ScoreRules) fact(name string, email evmail.Address, results DepResult) bool {
+	for _, condition := range s.Facts[name] {
+		if !condition.match(email, results) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c ScoreCondition) match(email evmail.Address, results DepResult) bool {
+	return c.matchResult(email, results) != c.Not
+}
+
+func (c ScoreCondition) matchResult(email evmail.Address, results DepResult) bool {
+	if c.LocalPart != "" {
+		if email == nil {
+			return false
+		}
+		if matched, _ := regexp.MatchString(c.LocalPart, email.Username()); !matched {
+			return false
+		}
+	}
+
+	if c.Validator == "" {
+		return true
+	}
+
+	result, ok := results[c.Validator]
+	if !ok || result == nil {
+		return false
+	}
+
+	if c.Outcome != "" && result.Outcome().String() != c.Outcome {
+		return false
+	}
+
+	if len(c.Errors) == 0 && len(c.Stages) == 0 && c.Code == 0 && len(c.Contains) == 0 && len(c.NotContains) == 0 {
+		return true
+	}
+
+	for _, err := range append(append([]error{}, result.Errors()...), result.Warnings()...) {
+		if c.matchError(err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c ScoreCondition) matchError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if len(c.Errors) > 0 && !containsAny(errorTypeNames(err), c.Errors) {
+		return false
+	}
+
+	if len(c.Stages) > 0 {
+		var smtpErr evsmtp.Error
+		if !errors.As(err, &smtpErr) || !containsAny([]string{evsmtp.StageName(smtpErr.Stage())}, c.Stages) {
+			return false
+		}
+	}
+
+	if c.Code != 0 {
+		var protoErr *textproto.Error
+		if !errors.As(err, &protoErr) || protoErr.Code != c.Code {
+			return false
+		}
+	}
+
+	text := strings.ToLower(err.Error())
+	if len(c.Contains) > 0 && !containsSubstring(text, c.Contains) {
+		return false
+	}
+
+	return !containsSubstring(text, c.NotContains)
+}
+
+// errorTypeNames returns names of types of err and wrapped errors
+func errorTypeNames(err error) (names []string) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		t := reflect.TypeOf(err)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		names = append(names, t.Name())
+	}
+
+	return names
+}
+
+func containsAny(values []string, wants []string) bool {
+	for _, value := range values {
+		for _, want := range wants {
+			if value == want {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func containsSubstring(text string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(text, strings.ToLower(substring)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+package ev
+
+import (
+	"embed"
+)
+
+//go:embed presets/score_*.yaml
+var scorePresets embed.FS
+
+// Names of ScoreRules presets
+const (
+	// MailboxValidatorScore is score of https://www.mailboxvalidator.com/, levels are valid and invalid
+	MailboxValidatorScore = "mailboxvalidator"
+	// CheckIfEmailExistScore is availability of https://github.com/reacherhq/check-if-email-exists,
+	// levels are safe, risky and invalid
+	CheckIfEmailExistScore = "check_if_email_exist"
+	// ASEmailVerifierScore is reachable of https://github.com/AfterShip/email-verifier, levels are yes, unknown and no
+	ASEmailVerifierScore = "as_email_verifier"
+)
+
+// ScorePresets returns names of presets for ScorePreset
+func ScorePresets() []string {
+	return []string{MailboxValidatorScore, CheckIfEmailExistScore, ASEmailVerifierScore}
+}
+
+// ScorePreset returns ScoreRules by name of preset
+func ScorePreset(name string) (ScoreRules, error) {
+	data, err := scorePresets.ReadFile("presets/score_" + name + ".yaml")
+	if err != nil {
+		return ScoreRules{}, newInvalidScoreRulesError("unknown preset %q", name)
+	}
+
+	return ParseScoreRules(data)
+}
@@ -0,0 +1,211 @@
+package ev_test
+
+import (
+	"net/textproto"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const scoreRulesYAML = `
+base: 10
+scale: 100
+facts:
+  syntax:
+    - validator: syntaxValidator
+      outcome: valid
+  rejected:
+    - validator: SMTPValidator
+      stages: [rcpts]
+      code: 550
+  greylisted:
+    - validator: SMTPValidator
+      contains: [greylist]
+  dotted:
+    - localPart: '\.'
+rules:
+  - name: syntax
+    when: [syntax]
+    weight: 50
+  - name: rejected
+    when: [rejected]
+    op: set
+    weight: 0
+  - name: greylisted
+    when: [greylisted, '!rejected']
+    op: atMost
+    weight: 30
+  - name: dotted
+    when: [dotted]
+    weight: 5
+  - name: atLeast
+    op: atLeast
+    weight: 1
+levels:
+  - name: low
+    min: 0
+  - name: high
+    min: 0.5
+`
+
+func TestParseScoreRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "yaml",
+			data: scoreRulesYAML,
+		},
+		{
+			name: "json",
+			data: `{"facts": {"valid": [{"validator": "MXValidator", "outcome": "valid"}]}, "rules": [{"name": "mx", "when": ["!valid"], "weight": 1}]}`,
+		},
+		{
+			name:    "unknown field",
+			data:    `{"rules": [{"name": "mx", "weigth": 1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown fact",
+			data:    `{"rules": [{"name": "mx", "when": ["unknown"], "weight": 1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown op",
+			data:    `{"rules": [{"name": "mx", "op": "mul", "weight": 1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown outcome",
+			data:    `{"facts": {"valid": [{"validator": "MXValidator", "outcome": "ok"}]}, "rules": []}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown stage",
+			data:    `{"facts": {"rcpt": [{"validator": "SMTPValidator", "stages": ["rcpt"]}]}, "rules": []}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid regexp",
+			data:    `{"facts": {"name": [{"localPart": "("}]}, "rules": []}`,
+			wantErr: true,
+		},
+		{
+			name:    "empty condition",
+			data:    `{"facts": {"empty": [{"outcome": "valid"}]}, "rules": []}`,
+			wantErr: true,
+		},
+		{
+			name:    "negative scale",
+			data:    `{"scale": -1, "rules": []}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ev.ParseScoreRules([]byte(tt.data))
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestScoreRules_Score(t *testing.T) {
+	rules, err := ev.ParseScoreRules([]byte(scoreRulesYAML))
+	require.NoError(t, err)
+
+	smtpResult := func(errs ...error) ev.ValidationResult {
+		return ev.NewResult(len(errs) == 0, errs, nil, ev.SMTPValidatorName)
+	}
+	rejected := evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "user unknown"})
+	greylisted := evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 451, Msg: "Greylisted"})
+
+	tests := []struct {
+		name      string
+		email     evmail.Address
+		result    ev.ValidationResult
+		want      float64
+		wantLevel string
+		wantRules map[string]float64
+	}{
+		{
+			name:  "valid",
+			email: evmail.FromString("user@domain.com"),
+			result: ev.NewDepValidatorResult(true, ev.DepResult{
+				ev.SyntaxValidatorName: ev.NewValidResult(ev.SyntaxValidatorName),
+				ev.SMTPValidatorName:   smtpResult(),
+			}),
+			want:      0.6,
+			wantLevel: "high",
+			wantRules: map[string]float64{"syntax": 50},
+		},
+		{
+			name:  "rejected",
+			email: evmail.FromString("first.last@domain.com"),
+			result: ev.NewDepValidatorResult(false, ev.DepResult{
+				ev.SyntaxValidatorName: ev.NewValidResult(ev.SyntaxValidatorName),
+				ev.SMTPValidatorName:   smtpResult(rejected),
+			}),
+			want:      0.05,
+			wantLevel: "low",
+			wantRules: map[string]float64{"syntax": 50, "rejected": -60, "dotted": 5},
+		},
+		{
+			name:  "greylisted",
+			email: evmail.FromString("user@domain.com"),
+			result: ev.NewDepValidatorResult(false, ev.DepResult{
+				ev.SyntaxValidatorName: ev.NewValidResult(ev.SyntaxValidatorName),
+				ev.SMTPValidatorName:   smtpResult(greylisted),
+			}),
+			want:      0.3,
+			wantLevel: "low",
+			wantRules: map[string]float64{"syntax": 50, "greylisted": -30},
+		},
+		{
+			name:      "single result without email",
+			result:    ev.NewResult(false, utils.Errs(ev.SyntaxError{}), nil, ev.SyntaxValidatorName),
+			want:      0.1,
+			wantLevel: "low",
+			wantRules: map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rules.Score(tt.email, tt.result)
+
+			require.InDelta(t, tt.want, got.Score, 1e-9)
+			require.Equal(t, tt.wantLevel, got.Level)
+			require.Len(t, got.Rules, len(rules.Rules))
+
+			gotRules := map[string]float64{}
+			for _, rule := range got.Rules {
+				if rule.Matched && rule.Delta != 0 {
+					gotRules[rule.Name] = rule.Delta
+				}
+			}
+			require.Equal(t, tt.wantRules, gotRules)
+		})
+	}
+}
+
+func TestScorePreset(t *testing.T) {
+	for _, name := range ev.ScorePresets() {
+		t.Run(name, func(t *testing.T) {
+			rules, err := ev.ScorePreset(name)
+			require.NoError(t, err)
+			require.NotEmpty(t, rules.Rules)
+		})
+	}
+
+	_, err := ev.ScorePreset("unknown")
+	require.Error(t, err)
+}
@@ -1,14 +1,28 @@
 package ev
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
 	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
 	"github.com/vmihailenco/msgpack"
+	"github.com/vmihailenco/msgpack/codes"
 )
 
 func init() {
 	msgpack.RegisterExt(evsmtp.ExtID(), new(DepsError))
 	msgpack.RegisterExt(evsmtp.ExtID(), new(AValidationResult))
+	msgpack.RegisterExt(evsmtp.ExtID(), new(PanicError))
+	msgpack.RegisterExt(evsmtp.ExtID(), new(RetryError))
+	msgpack.RegisterExt(evsmtp.ExtID(), new(RateLimitedError))
+	msgpack.RegisterExt(evsmtp.ExtID(), new(SubaddressError))
+	msgpack.RegisterExt(evsmtp.ExtID(), new(SuggestionError))
+	// typed results are registered to decode them from cache with their types, e.g. MXValidationResult for smtpValidator
+	msgpack.RegisterExt(evsmtp.ExtID(), new(mxValidationResult))
+	msgpack.RegisterExt(evsmtp.ExtID(), new(gravatarValidationResult))
+	msgpack.RegisterExt(evsmtp.ExtID(), new(subaddressValidationResult))
+	msgpack.RegisterExt(evsmtp.ExtID(), new(suggestionValidationResult))
 }
 
 // OtherValidator is ValidatorName for unknown Validator
@@ -20,6 +34,22 @@ type Validator interface {
 	Validate(input Input, results ...ValidationResult) ValidationResult
 }
 
+// ContextValidator is Validator, which supports cancellation and deadlines by context.Context
+type ContextValidator interface {
+	Validator
+	ValidateContext(ctx context.Context, input Input, results ...ValidationResult) ValidationResult
+}
+
+// ValidateContext validates input by ContextValidator.ValidateContext if validator implements it,
+// otherwise Validator.Validate is used and ctx is ignored.
+func ValidateContext(ctx context.Context, validator Validator, input Input, results ...ValidationResult) ValidationResult {
+	if ctxValidator, ok := validator.(ContextValidator); ok {
+		return ctxValidator.ValidateContext(ctx, input, results...)
+	}
+
+	return validator.Validate(input, results...)
+}
+
 // ChangeableValidationResult is ValidationResult with changeable errors and warnings
 type ChangeableValidationResult interface {
 	SetErrors([]error)
@@ -28,8 +58,10 @@ type ChangeableValidationResult interface {
 
 // ValidationResult is interface to represent result of validation
 type ValidationResult interface {
-	// IsValid is status of validation
+	// IsValid is status of validation, it is true only for OutcomeValid
 	IsValid() bool
+	// Outcome is tri-state status of validation
+	Outcome() Outcome
 	// Errors of result after validation
 	Errors() []error
 	// HasErrors checks for the presence of the Errors
@@ -48,6 +80,8 @@ type AValidationResult struct {
 	ErrorsVal   []error
 	WarningsVal []error
 	NameVal     ValidatorName
+	// UnknownVal marks invalid result as OutcomeUnknown
+	UnknownVal bool
 }
 
 // IsValid is status of validation
@@ -55,6 +89,18 @@ func (a *AValidationResult) IsValid() bool {
 	return a.IsValidVal
 }
 
+// Outcome is tri-state status of validation
+func (a *AValidationResult) Outcome() Outcome {
+	switch {
+	case a.IsValidVal:
+		return OutcomeValid
+	case a.UnknownVal:
+		return OutcomeUnknown
+	}
+
+	return OutcomeInvalid
+}
+
 // SetErrors sets errors
 func (a *AValidationResult) SetErrors(errors []error) {
 	a.ErrorsVal = errors
@@ -91,19 +137,41 @@ func (a *AValidationResult) ValidatorName() ValidatorName {
 	return a.NameVal
 }
 
+// aValidationResultMsgpackVersion marks msgpack layout of AValidationResult with UnknownVal.
+// The previous layout starts with IsValidVal, so results from old caches are decoded without UnknownVal.
+const aValidationResultMsgpackVersion uint8 = 1
+
 // EncodeMsgpack is used to fix this problem https://github.com/vmihailenco/msgpack/issues/294
 func (a *AValidationResult) EncodeMsgpack(enc *msgpack.Encoder) error {
 	return enc.EncodeMulti(
+		aValidationResultMsgpackVersion,
 		a.IsValidVal,
 		evsmtp.ErrorsToEVSMTPErrors(a.ErrorsVal),
 		evsmtp.ErrorsToEVSMTPErrors(a.WarningsVal),
 		a.NameVal,
+		a.UnknownVal,
 	)
 }
 
 // DecodeMsgpack is used to fix this problem https://github.com/vmihailenco/msgpack/issues/294
 func (a *AValidationResult) DecodeMsgpack(dec *msgpack.Decoder) error {
-	return dec.DecodeMulti(&a.IsValidVal, &a.ErrorsVal, &a.WarningsVal, &a.NameVal)
+	code, err := dec.PeekCode()
+	if err != nil {
+		return err
+	}
+	if code == codes.False || code == codes.True {
+		return dec.DecodeMulti(&a.IsValidVal, &a.ErrorsVal, &a.WarningsVal, &a.NameVal)
+	}
+
+	var version uint8
+	if err := dec.Decode(&version); err != nil {
+		return err
+	}
+	if version != aValidationResultMsgpackVersion {
+		return fmt.Errorf("msgpack: unknown version %d of AValidationResult", version)
+	}
+
+	return dec.DecodeMulti(&a.IsValidVal, &a.ErrorsVal, &a.WarningsVal, &a.NameVal, &a.UnknownVal)
 }
 
 type ValidationResultStruct = AValidationResult
@@ -119,7 +187,7 @@ func NewResult(isValid bool, errors []error, warnings []error, name ValidatorNam
 		name = OtherValidator
 	}
 
-	return &ValidationResultStruct{isValid, errors, warnings, name}
+	return &ValidationResultStruct{IsValidVal: isValid, ErrorsVal: errors, WarningsVal: warnings, NameVal: name}
 }
 
 var EmptyDeps = make([]ValidatorName, 0)
@@ -19,6 +19,21 @@ func (BanWordsUsernameError) Error() string {
 	return BanWordsUsernameErr
 }
 
+// Code returns BanWordsUsernameCode
+func (BanWordsUsernameError) Code() string {
+	return BanWordsUsernameCode
+}
+
+// Category returns PolicyCategory
+func (BanWordsUsernameError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns nil
+func (BanWordsUsernameError) Details() map[string]interface{} {
+	return nil
+}
+
 // NewBanWordsUsername instantiates BanWordsUsernameValidatorName validator
 func NewBanWordsUsername(inStrings contains.InStrings) Validator {
 	return banWordsUsernameValidator{d: inStrings}
@@ -0,0 +1,175 @@
+package ev
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evcache"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+)
+
+// DefaultBatchWorkers is default number of concurrent validations in BatchValidator
+const DefaultBatchWorkers = 10
+
+// batchQueueSize is size of queue of workers in BatchValidator
+const batchQueueSize = 100
+
+// BatchOrder defines order of results in BatchValidator
+type BatchOrder uint8
+
+// Orders of results
+const (
+	// InputOrder returns results in order of inputs
+	InputOrder BatchOrder = iota
+	// CompletionOrder returns results as soon as validations are finished
+	CompletionOrder
+)
+
+// BatchResult is result of validation of one input in BatchValidator
+type BatchResult struct {
+	// Index is position of Input in the input stream
+	Index  int
+	Input  Input
+	Result ValidationResult
+}
+
+// BatchValidatorDTO is DTO for NewBatchValidator
+type BatchValidatorDTO struct {
+	Validator Validator
+	// Workers is number of concurrent validations, DefaultBatchWorkers is used for 0
+	Workers int
+	Order   BatchOrder
+	// MemoSize is number of domains, which results are shared between inputs, evcache.DefaultMemoSize is used for 0
+	MemoSize int
+}
+
+// NewBatchValidator instantiates BatchValidator
+func NewBatchValidator(dto BatchValidatorDTO) *BatchValidator {
+	if dto.Validator == nil {
+		dto.Validator = NewDepBuilder(nil).Build()
+	}
+
+	if dto.Workers <= 0 {
+		dto.Workers = DefaultBatchWorkers
+	}
+
+	return &BatchValidator{
+		Validator: dto.Validator,
+		Workers:   dto.Workers,
+		Order:     dto.Order,
+		MemoSize:  dto.MemoSize,
+	}
+}
+
+// BatchValidator validates stream of inputs with bounded concurrency.
+// Workers take inputs from one shared queue, results of MX lookups and random RCPTs are shared
+// between inputs with the same domain by evcache.Memo, which is created for each call of Validate.
+type BatchValidator struct {
+	Validator Validator
+	Workers   int
+	Order     BatchOrder
+	MemoSize  int
+}
+
+// Validate validates inputs until the channel is closed or ctx is done.
+// The returned channel is closed after the last result and should be read to the end or ctx should be canceled.
+func (b *BatchValidator) Validate(ctx context.Context, inputs <-chan Input) <-chan BatchResult {
+	ctx = evcache.ContextWithMemo(ctx, evcache.NewMemo(evcache.MemoDTO{Size: b.MemoSize, Forget: evsmtp.IsTransient}))
+
+	queue := make(chan BatchResult, batchQueueSize)
+
+	completed := make(chan BatchResult, b.Workers)
+	wg := sync.WaitGroup{}
+	wg.Add(b.Workers)
+	for i := 0; i < b.Workers; i++ {
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				if ctx.Err() != nil {
+					continue
+				}
+				item.Result, _ = safeValidate(ctx, OtherValidator, b.Validator, item.Input)
+				select {
+				case completed <- item:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(queue)
+
+		for index := 0; ; index++ {
+			var input Input
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case input, ok = <-inputs:
+				if !ok {
+					return
+				}
+			}
+
+			select {
+			case queue <- BatchResult{Index: index, Input: input}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(completed)
+	}()
+
+	if b.Order == CompletionOrder {
+		return completed
+	}
+
+	return inputOrder(ctx, completed)
+}
+
+// inputOrder sorts results by BatchResult.Index
+func inputOrder(ctx context.Context, completed <-chan BatchResult) <-chan BatchResult {
+	ordered := make(chan BatchResult)
+
+	go func() {
+		defer close(ordered)
+
+		pending := make(map[int]BatchResult)
+		next := 0
+		for result := range completed {
+			pending[result.Index] = result
+			for {
+				nextResult, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+
+				select {
+				case ordered <- nextResult:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ordered
+}
+
+// InputsChan returns closed channel with inputs, it is used for BatchValidator.Validate
+func InputsChan(inputs ...Input) <-chan Input {
+	ch := make(chan Input, len(inputs))
+	for _, input := range inputs {
+		ch <- input
+	}
+	close(ch)
+
+	return ch
+}
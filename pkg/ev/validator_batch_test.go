@@ -0,0 +1,137 @@
+package ev_test
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/stretchr/testify/require"
+)
+
+func batchInputs(emails ...string) []ev.Input {
+	inputs := make([]ev.Input, len(emails))
+	for i, email := range emails {
+		inputs[i] = ev.NewInput(evmail.FromString(email))
+	}
+
+	return inputs
+}
+
+func countingLookupMX() (func(domain string) ([]*net.MX, error), map[string]int) {
+	mu := sync.Mutex{}
+	calls := make(map[string]int)
+
+	return func(domain string) ([]*net.MX, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[domain]++
+
+		return []*net.MX{{Host: "mx." + domain}}, nil
+	}, calls
+}
+
+func TestBatchValidator_Validate(t *testing.T) {
+	inputs := batchInputs(
+		"a@first.com",
+		"b@second.com",
+		"c@first.com",
+		"d@second.com",
+		"e@first.com",
+		"f@second.com",
+	)
+
+	tests := []struct {
+		name string
+		dto  ev.BatchValidatorDTO
+	}{
+		{
+			name: "input order",
+			dto:  ev.BatchValidatorDTO{Workers: 3},
+		},
+		{
+			name: "completion order",
+			dto:  ev.BatchValidatorDTO{Workers: 3, Order: ev.CompletionOrder},
+		},
+		{
+			name: "default workers",
+			dto:  ev.BatchValidatorDTO{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookupMX, calls := countingLookupMX()
+			tt.dto.Validator = ev.NewDepBuilder(ev.ValidatorMap{
+				ev.MXValidatorName: ev.NewMXValidator(lookupMX),
+			}).Build()
+
+			b := ev.NewBatchValidator(tt.dto)
+			got := make([]ev.BatchResult, 0, len(inputs))
+			for result := range b.Validate(context.Background(), ev.InputsChan(inputs...)) {
+				got = append(got, result)
+			}
+
+			if tt.dto.Order == ev.CompletionOrder {
+				sort.Slice(got, func(i, j int) bool {
+					return got[i].Index < got[j].Index
+				})
+			}
+
+			require.Len(t, got, len(inputs))
+			for i, result := range got {
+				require.Equal(t, i, result.Index)
+				require.Equal(t, inputs[i], result.Input)
+				require.True(t, result.Result.IsValid())
+			}
+			require.Equal(t, map[string]int{"first.com": 1, "second.com": 1}, calls)
+		})
+	}
+}
+
+// concurrentValidator is valid only if workers validators run concurrently
+type concurrentValidator struct {
+	mockValidator
+	started *sync.WaitGroup
+}
+
+func (c concurrentValidator) Validate(_ ev.Input, _ ...ev.ValidationResult) ev.ValidationResult {
+	c.started.Done()
+	done := make(chan struct{})
+	go func() {
+		c.started.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return ev.NewValidResult(ev.OtherValidator)
+	case <-time.After(time.Second):
+		return ev.NewResult(false, nil, nil, ev.OtherValidator)
+	}
+}
+
+func TestBatchValidator_Validate_SameDomain(t *testing.T) {
+	const workers = 3
+	started := &sync.WaitGroup{}
+	started.Add(workers)
+
+	b := ev.NewBatchValidator(ev.BatchValidatorDTO{Validator: concurrentValidator{started: started}, Workers: workers})
+	for result := range b.Validate(context.Background(), ev.InputsChan(batchInputs("a@gmail.com", "b@gmail.com", "c@gmail.com")...)) {
+		require.True(t, result.Result.IsValid(), "inputs with the same domain should be validated concurrently")
+	}
+}
+
+func TestBatchValidator_Validate_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	inputs := make(chan ev.Input)
+	b := ev.NewBatchValidator(ev.BatchValidatorDTO{Validator: ev.NewSyntaxValidator()})
+	for range b.Validate(ctx, inputs) {
+		t.Fatal("result is not expected")
+	}
+}
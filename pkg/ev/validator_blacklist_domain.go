@@ -16,6 +16,21 @@ func (BlackListDomainsError) Error() string {
 	return "BlackListDomainsError"
 }
 
+// Code returns BlackListDomainsCode
+func (BlackListDomainsError) Code() string {
+	return BlackListDomainsCode
+}
+
+// Category returns PolicyCategory
+func (BlackListDomainsError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns nil
+func (BlackListDomainsError) Details() map[string]interface{} {
+	return nil
+}
+
 // NewBlackListValidator instantiates BlackListDomainsValidatorName validator
 func NewBlackListValidator(d contains.InSet) Validator {
 	return blackListValidator{d: d}
@@ -19,6 +19,21 @@ func (BlackListEmailsError) Error() string {
 	return BlackListEmailsErr
 }
 
+// Code returns BlackListEmailsCode
+func (BlackListEmailsError) Code() string {
+	return BlackListEmailsCode
+}
+
+// Category returns PolicyCategory
+func (BlackListEmailsError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns nil
+func (BlackListEmailsError) Details() map[string]interface{} {
+	return nil
+}
+
 // NewBlackListEmailsValidator instantiates BlackListEmailsValidatorName validator
 func NewBlackListEmailsValidator(d contains.InSet) Validator {
 	return blackListEmailsValidator{d: d}
@@ -0,0 +1,120 @@
+package ev
+
+import (
+	"context"
+)
+
+// NotApplicableErr is text for NotApplicableError.Error
+const NotApplicableErr = "NotApplicableError"
+
+// NotApplicableError is warning of Conditional, if validator was not run because of Predicate
+type NotApplicableError struct{}
+
+func (NotApplicableError) Error() string {
+	return NotApplicableErr
+}
+
+// Code returns NotApplicableCode
+func (NotApplicableError) Code() string {
+	return NotApplicableCode
+}
+
+// Category returns PolicyCategory
+func (NotApplicableError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns nil
+func (NotApplicableError) Details() map[string]interface{} {
+	return nil
+}
+
+// NewNotApplicableResult returns valid result with NotApplicableError in warnings
+func NewNotApplicableResult(name ValidatorName) ValidationResult {
+	return NewResult(true, nil, []error{NotApplicableError{}}, name)
+}
+
+// Predicate decides by input and results of condition dependencies, whether validator should be run
+type Predicate func(input Input, results DepResult) bool
+
+// DepIsValid returns Predicate, which is true if result of validator by name is valid
+func DepIsValid(name ValidatorName) Predicate {
+	return func(_ Input, results DepResult) bool {
+		result, ok := results[name]
+		return ok && result.IsValid()
+	}
+}
+
+// DepIsInvalid returns Predicate, which is true if result of validator by name is invalid
+func DepIsInvalid(name ValidatorName) Predicate {
+	return func(_ Input, results DepResult) bool {
+		result, ok := results[name]
+		return ok && !result.IsValid()
+	}
+}
+
+// NewConditional creates Conditional, validator runs only if predicate is true for results of conditionDeps.
+// Otherwise, the result is NewNotApplicableResult with name.
+func NewConditional(name ValidatorName, validator Validator, predicate Predicate, conditionDeps ...ValidatorName) Validator {
+	validatorDeps := validator.GetDeps()
+	deps := append([]ValidatorName{}, validatorDeps...)
+	indexes := make(map[ValidatorName]int, len(deps)+len(conditionDeps))
+	for i, dep := range deps {
+		indexes[dep] = i
+	}
+	for _, dep := range conditionDeps {
+		if _, ok := indexes[dep]; !ok {
+			indexes[dep] = len(deps)
+			deps = append(deps, dep)
+		}
+	}
+
+	return Conditional{
+		Name:          name,
+		Validator:     validator,
+		Predicate:     predicate,
+		ConditionDeps: conditionDeps,
+		deps:          deps,
+		validatorDeps: len(validatorDeps),
+		indexes:       indexes,
+	}
+}
+
+// Conditional runs Validator only if Predicate is true
+type Conditional struct {
+	Name          ValidatorName
+	Validator     Validator
+	Predicate     Predicate
+	ConditionDeps []ValidatorName
+	deps          []ValidatorName
+	validatorDeps int
+	indexes       map[ValidatorName]int
+}
+
+// GetDeps returns dependencies of Validator and ConditionDeps
+func (c Conditional) GetDeps() []ValidatorName {
+	return c.deps
+}
+
+func (c Conditional) Validate(input Input, results ...ValidationResult) ValidationResult {
+	return c.ValidateContext(context.Background(), input, results...)
+}
+
+func (c Conditional) ValidateContext(ctx context.Context, input Input, results ...ValidationResult) ValidationResult {
+	conditionResults := make(DepResult, len(c.ConditionDeps))
+	for _, dep := range c.ConditionDeps {
+		if i := c.indexes[dep]; i < len(results) {
+			conditionResults[dep] = results[i]
+		}
+	}
+
+	if !c.Predicate(input, conditionResults) {
+		return NewNotApplicableResult(c.Name)
+	}
+
+	if c.validatorDeps < len(results) {
+		results = results[:c.validatorDeps]
+	}
+
+	return ValidateContext(ctx, c.Validator, input, results...)
+}
@@ -0,0 +1,107 @@
+package ev_test
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/stretchr/testify/require"
+)
+
+type resultsValidator struct {
+	mockValidator
+	t    *testing.T
+	want []ev.ValidationResult
+}
+
+func (r resultsValidator) Validate(_ ev.Input, results ...ev.ValidationResult) ev.ValidationResult {
+	require.Equal(r.t, r.want, results)
+
+	return validResult
+}
+
+func TestConditional_Validate(t *testing.T) {
+	syntaxResult := ev.NewValidResult(ev.SyntaxValidatorName)
+	whiteListValid := ev.NewValidResult(ev.WhiteListDomainValidatorName)
+	whiteListInvalid := ev.NewResult(false, []error{ev.WhiteListError{}}, nil, ev.WhiteListDomainValidatorName)
+
+	tests := []struct {
+		name      string
+		validator ev.Validator
+		results   []ev.ValidationResult
+		wantDeps  []ev.ValidatorName
+		want      ev.ValidationResult
+	}{
+		{
+			name: "predicate is true",
+			validator: ev.NewConditional(
+				ev.SMTPValidatorName,
+				resultsValidator{
+					mockValidator: mockValidator{deps: []ev.ValidatorName{ev.SyntaxValidatorName}},
+					t:             t,
+					want:          []ev.ValidationResult{syntaxResult},
+				},
+				ev.DepIsInvalid(ev.WhiteListDomainValidatorName),
+				ev.WhiteListDomainValidatorName,
+			),
+			results:  []ev.ValidationResult{syntaxResult, whiteListInvalid},
+			wantDeps: []ev.ValidatorName{ev.SyntaxValidatorName, ev.WhiteListDomainValidatorName},
+			want:     validResult,
+		},
+		{
+			name: "predicate is false",
+			validator: ev.NewConditional(
+				ev.SMTPValidatorName,
+				notRunValidator{mockValidator{deps: []ev.ValidatorName{ev.SyntaxValidatorName}}, t},
+				ev.DepIsInvalid(ev.WhiteListDomainValidatorName),
+				ev.WhiteListDomainValidatorName,
+			),
+			results:  []ev.ValidationResult{syntaxResult, whiteListValid},
+			wantDeps: []ev.ValidatorName{ev.SyntaxValidatorName, ev.WhiteListDomainValidatorName},
+			want:     ev.NewNotApplicableResult(ev.SMTPValidatorName),
+		},
+		{
+			name: "condition dependency is dependency of validator",
+			validator: ev.NewConditional(
+				ev.GravatarValidatorName,
+				resultsValidator{
+					mockValidator: mockValidator{deps: []ev.ValidatorName{ev.SyntaxValidatorName}},
+					t:             t,
+					want:          []ev.ValidationResult{syntaxResult},
+				},
+				ev.DepIsValid(ev.SyntaxValidatorName),
+				ev.SyntaxValidatorName,
+			),
+			results:  []ev.ValidationResult{syntaxResult},
+			wantDeps: []ev.ValidatorName{ev.SyntaxValidatorName},
+			want:     validResult,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantDeps, tt.validator.GetDeps())
+			require.Equal(t, tt.want, tt.validator.Validate(ev.NewInput(validEmail), tt.results...))
+		})
+	}
+}
+
+func TestConditional_DepValidator(t *testing.T) {
+	v := ev.NewDepBuilder(ev.ValidatorMap{
+		ev.FreeValidatorName: ev.NewFreeValidator(mockContains{t: t, want: validEmail.Domain(), ret: false}),
+		ev.GravatarValidatorName: ev.NewConditional(
+			ev.GravatarValidatorName,
+			notRunValidator{t: t},
+			ev.DepIsInvalid(ev.FreeValidatorName),
+			ev.FreeValidatorName,
+		),
+	}).Build().Validate(ev.NewInput(validEmail))
+
+	require.True(t, v.IsValid())
+	require.Equal(t,
+		ev.NewNotApplicableResult(ev.GravatarValidatorName),
+		v.(ev.DepValidationResult).GetResults()[ev.GravatarValidatorName],
+	)
+}
+
+func TestNotApplicableError_Error(t *testing.T) {
+	require.Equal(t, ev.NotApplicableErr, ev.NotApplicableError{}.Error())
+}
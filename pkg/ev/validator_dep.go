@@ -1,7 +1,14 @@
 package ev
 
 import (
+	"context"
+	"fmt"
+	"runtime/debug"
 	"sync"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
 )
 
 // DepValidatorName is name of validator with dependencies
@@ -22,6 +29,59 @@ func (*DepsError) Error() string {
 	return "DepsError"
 }
 
+// Code returns DepsCode
+func (*DepsError) Code() string {
+	return DepsCode
+}
+
+// Category returns PolicyCategory, validator was not run because of its dependencies
+func (*DepsError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns nil
+func (*DepsError) Details() map[string]interface{} {
+	return nil
+}
+
+// NewPanicError creates PanicError
+func NewPanicError(name ValidatorName, value interface{}, stack []byte) error {
+	return &PanicError{
+		Validator: name,
+		Value:     value,
+		Stack:     stack,
+	}
+}
+
+// PanicError is error of validator, which panicked during validation in DepValidator
+type PanicError struct {
+	Validator ValidatorName
+	Value     interface{}
+	Stack     []byte
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("PanicError: validator %q panicked: %v", p.Validator, p.Value)
+}
+
+// Code returns PanicCode
+func (p *PanicError) Code() string {
+	return PanicCode
+}
+
+// Category returns TransientCategory
+func (p *PanicError) Category() string {
+	return TransientCategory
+}
+
+// Details returns validator and panic value
+func (p *PanicError) Details() map[string]interface{} {
+	return map[string]interface{}{
+		"validator": string(p.Validator),
+		"value":     fmt.Sprint(p.Value),
+	}
+}
+
 // NewDepValidator instantiates DepValidatorName validator
 func NewDepValidator(deps ValidatorMap) Validator {
 	return DepValidator{Deps: deps}
@@ -30,13 +90,47 @@ func NewDepValidator(deps ValidatorMap) Validator {
 type DepValidator struct {
 	AValidatorWithoutDeps
 	Deps ValidatorMap
+	// Policy defines skipping of validators, RunAllPolicy is default
+	Policy ExecutionPolicy
+	// Expensive validators are skipped by SkipExpensivePolicy, DefaultExpensiveValidators is used for nil
+	Expensive []ValidatorName
+	// Blocking validators lead to skipping of Expensive validators, DefaultBlockingValidators is used for nil
+	Blocking []ValidatorName
+	// Timeouts limit time of validators by names, the result of timed out validator contains TimeoutError
+	Timeouts map[ValidatorName]time.Duration
+	// Timeout is total time budget of validation, 0 means without limit
+	Timeout time.Duration
+	// After contains names of validators, which should be finished before start of validator by name,
+	// their results are not passed to the validator
+	After map[ValidatorName][]ValidatorName
+}
+
+func (d DepValidator) Validate(input Input, results ...ValidationResult) ValidationResult {
+	return d.ValidateContext(context.Background(), input, results...)
 }
 
-func (d DepValidator) Validate(input Input, _ ...ValidationResult) ValidationResult {
+// ValidateContext runs nested validators and passes ctx in each of them
+func (d DepValidator) ValidateContext(ctx context.Context, input Input, _ ...ValidationResult) ValidationResult {
+	if d.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.Timeout)
+		defer cancel()
+	}
+
 	var waiters, waitersMutex = make(map[ValidatorName][]*sync.WaitGroup), sync.RWMutex{}
 	var validationResultsByName, validationResultsMutex = make(map[ValidatorName]ValidationResult), sync.RWMutex{}
-	var isValid = true
+	// brokenByName contains validators, which panicked, timed out or were not run because of broken dependencies
+	var brokenByName = make(map[ValidatorName]bool)
+	// skippedByName contains validators, which were skipped by ExecutionPolicy
+	var skippedByName = make(map[ValidatorName]bool)
+	var isValid, hasInvalid, hasBlockingInvalid = true, false, false
 	var starter, finisher = sync.WaitGroup{}, sync.WaitGroup{}
+	var trace = Trace{Start: time.Now(), Validators: make([]ValidatorTrace, 0, len(d.Deps))}
+	var waitGraph, graphErr = d.waitGraph()
+	if graphErr != nil {
+		return d.brokenResult(graphErr)
+	}
+	var expensive, blocking = d.expensive(), d.blocking()
 	starter.Add(1)
 	finisher.Add(len(d.Deps))
 
@@ -47,54 +141,116 @@ func (d DepValidator) Validate(input Input, _ ...ValidationResult) ValidationRes
 		var ok bool
 
 		deps = validator.GetDeps()
-		if len(deps) > 0 {
+		if waits := waitGraph[key]; len(waits) > 0 {
 			depWaiter = &sync.WaitGroup{}
-			depWaiter.Add(len(deps))
+			depWaiter.Add(len(waits))
 
-			for _, dep := range deps {
-				if depWaiters, ok = waiters[dep]; !ok {
+			for _, wait := range waits {
+				if depWaiters, ok = waiters[wait]; !ok {
 					depWaiters = make([]*sync.WaitGroup, 0)
 				}
-				waiters[dep] = append(depWaiters, depWaiter)
+				waiters[wait] = append(depWaiters, depWaiter)
 			}
 		}
 
-		go func(key ValidatorName, validator Validator, depWaiter *sync.WaitGroup) {
+		go func(key ValidatorName, validator Validator, deps []ValidatorName, depWaiter *sync.WaitGroup) {
 			var results []ValidationResult
+			var hasBrokenDeps, hasSkippedDeps, isBroken bool
+			var skipReason string
 
-			// TODO add recover
 			starter.Wait()
 			if depWaiter != nil {
 				depWaiter.Wait()
+			}
 
+			validationResultsMutex.RLock()
+			if len(deps) > 0 {
 				results = make([]ValidationResult, len(deps))
-				validationResultsMutex.RLock()
 				for i, dep := range deps {
 					results[i] = validationResultsByName[dep]
+					hasBrokenDeps = hasBrokenDeps || brokenByName[dep]
+					hasSkippedDeps = hasSkippedDeps || skippedByName[dep]
 				}
-				validationResultsMutex.RUnlock()
 			}
+			skipReason = d.skipReason(expensive[key], hasInvalid, hasBlockingInvalid)
+			validationResultsMutex.RUnlock()
+
+			var result ValidationResult
+			validatorTrace := ValidatorTrace{Validator: key, Start: time.Now()}
+			switch {
+			case hasSkippedDeps:
+				skipReason = SkipReasonDepSkipped
+				result = NewSkippedResult(key, skipReason)
+				validatorTrace.Status, validatorTrace.Reason = TraceSkipped, skipReason
+			case hasBrokenDeps:
+				result, isBroken = NewResultWithOutcome(OutcomeUnknown, utils.Errs(NewDepsError()), nil, key), true
+				validatorTrace.Status = TraceDepsError
+			case skipReason != "":
+				result = NewSkippedResult(key, skipReason)
+				validatorTrace.Status, validatorTrace.Reason = TraceSkipped, skipReason
+			default:
+				stagesTrace := evsmtp.NewStagesTrace()
+				result, isBroken = timeoutValidate(
+					evsmtp.ContextWithStagesTrace(ctx, stagesTrace),
+					key, d.Timeouts[key], validator, input, results...,
+				)
+				stagesTrace.Finish()
+				validatorTrace.Status, validatorTrace.Reason = traceStatus(result)
+				validatorTrace.Stages = stagesTrace.Timings()
+			}
+			validatorTrace.Duration = time.Since(validatorTrace.Start)
 
-			var result = validator.Validate(input, results...)
 			validationResultsMutex.Lock()
+			trace.Validators = append(trace.Validators, validatorTrace)
 			validationResultsByName[key] = result
+			brokenByName[key] = isBroken
+			skippedByName[key] = skipReason != ""
 			isValid = isValid && result.IsValid()
+			if !result.IsValid() && skipReason == "" {
+				hasInvalid = true
+				hasBlockingInvalid = hasBlockingInvalid || blocking[key]
+			}
 			validationResultsMutex.Unlock()
 
 			waitersMutex.RLock()
-			if depWaiters, ok = waiters[key]; ok {
+			if depWaiters, ok := waiters[key]; ok {
 				for _, depWaiter := range depWaiters {
 					depWaiter.Done()
 				}
 			}
 			waitersMutex.RUnlock()
 			finisher.Done()
-		}(key, validator, depWaiter)
+		}(key, validator, deps, depWaiter)
 	}
 	starter.Done()
 	finisher.Wait()
+	trace.Duration = time.Since(trace.Start)
+	trace.sort()
+
+	return NewDepValidatorResultWithTrace(isValid, validationResultsByName, trace)
+}
+
+// brokenResult returns unknown results with err for all validators, they are not run,
+// because DepValidator would wait for absent or cyclic dependencies
+func (d DepValidator) brokenResult(err error) ValidationResult {
+	results := make(DepResult, len(d.Deps))
+	for name := range d.Deps {
+		results[name] = NewResultWithOutcome(OutcomeUnknown, utils.Errs(err), nil, name)
+	}
 
-	return NewDepValidatorResult(isValid, validationResultsByName)
+	return NewDepValidatorResult(false, results)
+}
+
+// safeValidate runs validator and converts its panic in result with PanicError
+func safeValidate(ctx context.Context, key ValidatorName, validator Validator, input Input, results ...ValidationResult) (result ValidationResult, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = NewResultWithOutcome(OutcomeUnknown, utils.Errs(NewPanicError(key, r, debug.Stack())), nil, key)
+			panicked = true
+		}
+	}()
+
+	return ValidateContext(ctx, validator, input, results...), false
 }
 
 // DepResult is alias for results of nested validators
@@ -104,6 +260,8 @@ type DepResult map[ValidatorName]ValidationResult
 type DepValidationResult interface {
 	ValidationResult
 	GetResults() DepResult
+	// GetTrace returns execution trace of nested validators
+	GetTrace() Trace
 }
 
 // NewDepValidatorResult returns DepValidatorName result
@@ -114,19 +272,51 @@ func NewDepValidatorResult(isValid bool, results DepResult) ValidationResult {
 	}
 }
 
+// NewDepValidatorResultWithTrace returns DepValidatorName result with execution trace
+func NewDepValidatorResultWithTrace(isValid bool, results DepResult, trace Trace) ValidationResult {
+	return depValidationResult{
+		isValid: isValid,
+		results: results,
+		trace:   trace,
+	}
+}
+
 type depValidationResult struct {
 	isValid bool
 	results DepResult
+	trace   Trace
 }
 
 func (d depValidationResult) GetResults() DepResult {
 	return d.results
 }
 
+func (d depValidationResult) GetTrace() Trace {
+	return d.trace
+}
+
 func (d depValidationResult) IsValid() bool {
 	return d.isValid
 }
 
+// Outcome is OutcomeInvalid if any nested result is invalid,
+// OutcomeUnknown if other invalid results are unknown
+func (d depValidationResult) Outcome() Outcome {
+	if d.isValid {
+		return OutcomeValid
+	}
+
+	results := make([]ValidationResult, 0, len(d.results))
+	for _, result := range d.results {
+		results = append(results, result)
+	}
+	if outcome := depsOutcome(results...); outcome == OutcomeUnknown {
+		return outcome
+	}
+
+	return OutcomeInvalid
+}
+
 func (d depValidationResult) Errors() (errors []error) {
 	for _, result := range d.GetResults() {
 		errors = append(errors, result.Errors()...)
@@ -1,6 +1,11 @@
 package ev
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/emirpasic/gods/sets/hashset"
 	"github.com/prodadidb/go-email-validator/pkg/ev/contains"
 	"github.com/prodadidb/go-email-validator/pkg/ev/disposable"
@@ -47,6 +52,14 @@ func NewDepBuilder(validators ValidatorMap) *DepBuilder {
 // DepBuilder is used to form Validator
 type DepBuilder struct {
 	Validators ValidatorMap
+	Policy     ExecutionPolicy
+	Expensive  []ValidatorName
+	Blocking   []ValidatorName
+	Timeouts   map[ValidatorName]time.Duration
+	Timeout    time.Duration
+	After      map[ValidatorName][]ValidatorName
+	// Registry is used by SetByName, DefaultRegistry is used if it is nil
+	Registry *Registry
 }
 
 // Set sets validator by ValidatorName
@@ -56,6 +69,30 @@ func (d *DepBuilder) Set(name ValidatorName, validator Validator) *DepBuilder {
 	return d
 }
 
+// SetRegistry sets Registry for SetByName
+func (d *DepBuilder) SetRegistry(registry *Registry) *DepBuilder {
+	d.Registry = registry
+
+	return d
+}
+
+// SetByName instantiates validator by Factory from Registry with params and sets it by name.
+// params are default if nil, UnknownFactoryError or ParamsError is returned if validator could not be instantiated.
+func (d *DepBuilder) SetByName(name ValidatorName, params interface{}) error {
+	registry := d.Registry
+	if registry == nil {
+		registry = DefaultRegistry()
+	}
+
+	validator, err := registry.New(name, params)
+	if err != nil {
+		return err
+	}
+	d.Set(name, validator)
+
+	return nil
+}
+
 // Get returns validator by ValidatorName
 func (d *DepBuilder) Get(name ValidatorName) Validator {
 	if d.Has(name) {
@@ -87,7 +124,187 @@ func (d *DepBuilder) Delete(names ...ValidatorName) *DepBuilder {
 	return d
 }
 
-// Build builds Validator based on configuration
+// SetPolicy sets ExecutionPolicy
+func (d *DepBuilder) SetPolicy(policy ExecutionPolicy) *DepBuilder {
+	d.Policy = policy
+
+	return d
+}
+
+// SetExpensive sets names of expensive validators, they are skipped by SkipExpensivePolicy
+func (d *DepBuilder) SetExpensive(names ...ValidatorName) *DepBuilder {
+	d.Expensive = append([]ValidatorName{}, names...)
+
+	return d
+}
+
+// SetBlocking sets names of blocking validators, they lead to skipping of expensive validators by SkipExpensivePolicy
+func (d *DepBuilder) SetBlocking(names ...ValidatorName) *DepBuilder {
+	d.Blocking = append([]ValidatorName{}, names...)
+
+	return d
+}
+
+// SetTimeout sets timeout of validator by ValidatorName
+func (d *DepBuilder) SetTimeout(name ValidatorName, timeout time.Duration) *DepBuilder {
+	if d.Timeouts == nil {
+		d.Timeouts = make(map[ValidatorName]time.Duration)
+	}
+	d.Timeouts[name] = timeout
+
+	return d
+}
+
+// SetAfter sets names of validators, which should be finished before start of validator.
+// Unlike dependencies of Validator.GetDeps, their results are not passed to the validator.
+func (d *DepBuilder) SetAfter(name ValidatorName, after ...ValidatorName) *DepBuilder {
+	if d.After == nil {
+		d.After = make(map[ValidatorName][]ValidatorName)
+	}
+	d.After[name] = append([]ValidatorName{}, after...)
+
+	return d
+}
+
+// SetTotalTimeout sets time budget for the whole validation
+func (d *DepBuilder) SetTotalTimeout(timeout time.Duration) *DepBuilder {
+	d.Timeout = timeout
+
+	return d
+}
+
+// Build builds Validator based on configuration, dependencies are not checked.
+// Use BuildE to get UnknownDepError or CycleDepError, otherwise DepValidator returns them in results.
 func (d *DepBuilder) Build() Validator {
-	return NewDepValidator(d.Validators)
+	return d.build()
+}
+
+func (d *DepBuilder) build() DepValidator {
+	return DepValidator{
+		Deps:      d.Validators,
+		Policy:    d.Policy,
+		Expensive: d.Expensive,
+		Blocking:  d.Blocking,
+		Timeouts:  d.Timeouts,
+		Timeout:   d.Timeout,
+		After:     d.After,
+	}
+}
+
+// BuildE checks dependencies of validators and builds Validator based on configuration.
+// UnknownDepError or CycleDepError is returned if DepValidator could not be executed with the validators.
+func (d *DepBuilder) BuildE() (Validator, error) {
+	if _, err := d.Order(); err != nil {
+		return nil, err
+	}
+
+	return d.build(), nil
+}
+
+// Order returns names of validators in execution order, each validator goes after its dependencies
+// and validators, which are required by ExecutionPolicy
+func (d *DepBuilder) Order() ([]ValidatorName, error) {
+	graph, err := d.build().depsGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	return graph.order()
+}
+
+// UnknownDepError is returned if validator depends on validator, which is absent in ValidatorMap
+type UnknownDepError struct {
+	Validator ValidatorName
+	Dep       ValidatorName
+}
+
+func (u *UnknownDepError) Error() string {
+	return fmt.Sprintf("UnknownDepError: validator %q depends on unknown validator %q", u.Validator, u.Dep)
+}
+
+// CycleDepError is returned if validators depend on each other
+type CycleDepError struct {
+	Cycle []ValidatorName
+}
+
+func (c *CycleDepError) Error() string {
+	names := make([]string, len(c.Cycle))
+	for i, name := range c.Cycle {
+		names[i] = name.String()
+	}
+
+	return fmt.Sprintf("CycleDepError: dependency cycle %s", strings.Join(names, " -> "))
+}
+
+// DepsOrder sorts validators topologically by Validator.GetDeps.
+// Validators are traversed by names in alphabetical order, so the result is stable.
+func DepsOrder(validators ValidatorMap) ([]ValidatorName, error) {
+	graph := make(depsGraph, len(validators))
+	for name, validator := range validators {
+		graph[name] = validator.GetDeps()
+	}
+
+	return graph.order()
+}
+
+// depsGraph contains names of validators, which should be finished before start of validator
+type depsGraph map[ValidatorName][]ValidatorName
+
+func (g depsGraph) order() ([]ValidatorName, error) {
+	// zero state means that validator is not visited
+	const (
+		inProgress = iota + 1
+		visited
+	)
+
+	names := make([]ValidatorName, 0, len(g))
+	for name := range g {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(l, r int) bool {
+		return names[l] < names[r]
+	})
+
+	order := make([]ValidatorName, 0, len(g))
+	states := make(map[ValidatorName]int, len(g))
+	path := make([]ValidatorName, 0)
+
+	var visit func(name ValidatorName) error
+	visit = func(name ValidatorName) error {
+		switch states[name] {
+		case visited:
+			return nil
+		case inProgress:
+			for i, pathName := range path {
+				if pathName == name {
+					cycle := append(append([]ValidatorName{}, path[i:]...), name)
+					return &CycleDepError{Cycle: cycle}
+				}
+			}
+		}
+
+		states[name] = inProgress
+		path = append(path, name)
+		for _, dep := range g[name] {
+			if _, ok := g[dep]; !ok {
+				return &UnknownDepError{Validator: name, Dep: dep}
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		states[name] = visited
+		order = append(order, name)
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
 }
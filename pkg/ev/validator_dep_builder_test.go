@@ -3,8 +3,10 @@ package ev_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDepBuilder_Build(t *testing.T) {
@@ -194,3 +196,183 @@ func TestDepBuilder_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestDepBuilder_BuildE(t *testing.T) {
+	tests := []struct {
+		name       string
+		validators ev.ValidatorMap
+		wantErr    error
+	}{
+		{
+			name:       "default",
+			validators: nil,
+			wantErr:    nil,
+		},
+		{
+			name: "unknown dependency",
+			validators: ev.ValidatorMap{
+				ev.SyntaxValidatorName: ev.NewSyntaxValidator(),
+				ev.SMTPValidatorName:   ev.NewSMTPValidator(nil),
+			},
+			wantErr: &ev.UnknownDepError{Validator: ev.SMTPValidatorName, Dep: ev.MXValidatorName},
+		},
+		{
+			name: "cycle",
+			validators: ev.ValidatorMap{
+				"a":    mockValidator{deps: []ev.ValidatorName{"b"}},
+				"b":    mockValidator{deps: []ev.ValidatorName{"c"}},
+				"c":    mockValidator{deps: []ev.ValidatorName{"b"}},
+				"free": mockValidator{},
+			},
+			wantErr: &ev.CycleDepError{Cycle: []ev.ValidatorName{"b", "c", "b"}},
+		},
+		{
+			name: "self dependency",
+			validators: ev.ValidatorMap{
+				"a": mockValidator{deps: []ev.ValidatorName{"a"}},
+			},
+			wantErr: &ev.CycleDepError{Cycle: []ev.ValidatorName{"a", "a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ev.NewDepBuilder(tt.validators).BuildE()
+			require.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				require.NotNil(t, got)
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
+
+func TestDepBuilder_Build_BrokenDeps(t *testing.T) {
+	unknownErr := &ev.UnknownDepError{Validator: ev.SMTPValidatorName, Dep: ev.MXValidatorName}
+	cycleErr := &ev.CycleDepError{Cycle: []ev.ValidatorName{"a", "b", "a"}}
+
+	tests := []struct {
+		name      string
+		validator func() ev.Validator
+		wantErr   error
+	}{
+		{
+			name: "unknown dependency of builder",
+			validator: func() ev.Validator {
+				return ev.NewDepBuilder(ev.ValidatorMap{
+					ev.SyntaxValidatorName: ev.NewSyntaxValidator(),
+					ev.SMTPValidatorName:   ev.NewSMTPValidator(nil),
+				}).Build()
+			},
+			wantErr: unknownErr,
+		},
+		{
+			name: "unknown dependency of DepValidator",
+			validator: func() ev.Validator {
+				return ev.NewDepValidator(ev.ValidatorMap{
+					ev.SyntaxValidatorName: ev.NewSyntaxValidator(),
+					ev.SMTPValidatorName:   ev.NewSMTPValidator(nil),
+				})
+			},
+			wantErr: unknownErr,
+		},
+		{
+			name: "cycle",
+			validator: func() ev.Validator {
+				return ev.NewDepValidator(ev.ValidatorMap{
+					"a": mockValidator{deps: []ev.ValidatorName{"b"}},
+					"b": mockValidator{deps: []ev.ValidatorName{"a"}},
+				})
+			},
+			wantErr: cycleErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validator ev.Validator
+			require.NotPanics(t, func() {
+				validator = tt.validator()
+			})
+
+			got := validator.Validate(ev.NewInput(GetValidTestEmail()))
+			require.Equal(t, ev.OutcomeUnknown, got.Outcome())
+			require.NotEmpty(t, got.(ev.DepValidationResult).GetResults())
+			for _, result := range got.(ev.DepValidationResult).GetResults() {
+				require.Equal(t, []error{tt.wantErr}, result.Errors())
+			}
+		})
+	}
+}
+
+func TestDepBuilder_Order(t *testing.T) {
+	got, err := ev.NewDepBuilder(nil).Order()
+	require.NoError(t, err)
+	require.Equal(t, []ev.ValidatorName{
+		ev.DisposableValidatorName,
+		ev.MXValidatorName,
+		ev.RoleValidatorName,
+		ev.SyntaxValidatorName,
+		ev.SMTPValidatorName,
+	}, got)
+}
+
+func TestDepBuilder_Order_Policy(t *testing.T) {
+	validators := ev.ValidatorMap{
+		ev.SMTPValidatorName:       mockValidator{},
+		ev.SyntaxValidatorName:     mockValidator{},
+		ev.DisposableValidatorName: mockValidator{},
+	}
+
+	got, err := ev.NewDepBuilder(validators).SetPolicy(ev.SkipExpensivePolicy).Order()
+	require.NoError(t, err)
+	require.Equal(t, []ev.ValidatorName{
+		ev.DisposableValidatorName,
+		ev.SyntaxValidatorName,
+		ev.SMTPValidatorName,
+	}, got)
+
+	validators[ev.SyntaxValidatorName] = mockValidator{deps: []ev.ValidatorName{ev.SMTPValidatorName}}
+	_, err = ev.NewDepBuilder(validators).SetPolicy(ev.SkipExpensivePolicy).BuildE()
+	require.Equal(t, &ev.CycleDepError{Cycle: []ev.ValidatorName{
+		ev.SMTPValidatorName,
+		ev.SyntaxValidatorName,
+		ev.SMTPValidatorName,
+	}}, err)
+}
+
+func TestDepBuilder_SetAfter(t *testing.T) {
+	builder := ev.NewDepBuilder(ev.ValidatorMap{
+		"a": testSleep{0, newMockValidator(true), nil},
+		"z": testSleep{20 * time.Millisecond, newMockValidator(false), nil},
+	}).SetAfter("a", "z")
+
+	order, err := builder.Order()
+	require.NoError(t, err)
+	require.Equal(t, []ev.ValidatorName{"z", "a"}, order)
+
+	got := builder.Build().Validate(ev.NewInput(GetValidTestEmail())).(ev.DepValidationResult)
+	require.Empty(t, builder.Get("a").GetDeps())
+	// result of "z" is not passed to "a"
+	require.True(t, got.GetResults()["a"].IsValid())
+	require.False(t, got.GetResults()["z"].IsValid())
+
+	trace := got.GetTrace().Validators
+	require.Len(t, trace, 2)
+	require.Equal(t, ev.ValidatorName("z"), trace[0].Validator)
+	require.Equal(t, ev.ValidatorName("a"), trace[1].Validator)
+	require.False(t, trace[1].Start.Before(trace[0].Start.Add(trace[0].Duration)))
+	require.Equal(t, ev.TraceValid, trace[1].Status)
+
+	_, err = builder.SetAfter("z", "a").BuildE()
+	require.Equal(t, &ev.CycleDepError{Cycle: []ev.ValidatorName{"a", "z", "a"}}, err)
+}
+
+func TestCycleDepError_Error(t *testing.T) {
+	err := &ev.CycleDepError{Cycle: []ev.ValidatorName{"a", "b", "a"}}
+	require.Equal(t, "CycleDepError: dependency cycle a -> b -> a", err.Error())
+}
+
+func TestUnknownDepError_Error(t *testing.T) {
+	err := &ev.UnknownDepError{Validator: ev.SMTPValidatorName, Dep: ev.MXValidatorName}
+	require.Equal(t, `UnknownDepError: validator "SMTPValidator" depends on unknown validator "MXValidator"`, err.Error())
+}
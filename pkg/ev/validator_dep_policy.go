@@ -0,0 +1,194 @@
+package ev
+
+// ExecutionPolicy defines, which validators DepValidator skips after failures of other validators
+type ExecutionPolicy uint8
+
+// Execution policies
+const (
+	// RunAllPolicy runs all validators
+	RunAllPolicy ExecutionPolicy = iota
+	// StopOnFirstErrorPolicy runs validators one by one in DepsOrder and skips the rest after the first invalid result
+	StopOnFirstErrorPolicy
+	// SkipExpensivePolicy runs expensive validators after blocking validators
+	// and skips expensive validators if any blocking validator is invalid
+	SkipExpensivePolicy
+)
+
+var executionPolicyNames = map[ExecutionPolicy]string{
+	RunAllPolicy:           "runAll",
+	StopOnFirstErrorPolicy: "stopOnFirstError",
+	SkipExpensivePolicy:    "skipExpensive",
+}
+
+func (e ExecutionPolicy) String() string {
+	return executionPolicyNames[e]
+}
+
+// ExecutionPolicyByName returns ExecutionPolicy by its String
+func ExecutionPolicyByName(name string) (ExecutionPolicy, bool) {
+	for policy, policyName := range executionPolicyNames {
+		if policyName == name {
+			return policy, true
+		}
+	}
+
+	return 0, false
+}
+
+// DefaultExpensiveValidators are used as expensive validators if DepValidator.Expensive is nil
+var DefaultExpensiveValidators = []ValidatorName{SMTPValidatorName, GravatarValidatorName}
+
+// DefaultBlockingValidators are used as blocking validators if DepValidator.Blocking is nil
+var DefaultBlockingValidators = []ValidatorName{
+	SyntaxValidatorName,
+	DisposableValidatorName,
+	BlackListEmailsValidatorName,
+	BlackListDomainsValidatorName,
+	WhiteListDomainValidatorName,
+	BanWordsUsernameValidatorName,
+}
+
+// Reasons of SkippedError
+const (
+	SkipReasonFirstError      = "previous validator is invalid"
+	SkipReasonBlockingInvalid = "blocking validator is invalid"
+	SkipReasonDepSkipped      = "dependency was skipped"
+)
+
+// SkippedErr is text for SkippedError.Error
+const SkippedErr = "SkippedError"
+
+// NewSkippedError creates SkippedError
+func NewSkippedError(reason string) error {
+	return &SkippedError{Reason: reason}
+}
+
+// SkippedError is error of validator, which was not run because of ExecutionPolicy
+type SkippedError struct {
+	Reason string
+}
+
+func (s *SkippedError) Error() string {
+	if s.Reason == "" {
+		return SkippedErr
+	}
+
+	return SkippedErr + ": " + s.Reason
+}
+
+// Code returns SkippedCode
+func (s *SkippedError) Code() string {
+	return SkippedCode
+}
+
+// Category returns PolicyCategory
+func (s *SkippedError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns reason of skipping
+func (s *SkippedError) Details() map[string]interface{} {
+	return map[string]interface{}{"reason": s.Reason}
+}
+
+// NewSkippedResult returns unknown result of validator, which was not run because of ExecutionPolicy
+func NewSkippedResult(name ValidatorName, reason string) ValidationResult {
+	return NewResultWithOutcome(OutcomeUnknown, []error{NewSkippedError(reason)}, nil, name)
+}
+
+func namesSet(names []ValidatorName, defaultNames []ValidatorName) map[ValidatorName]bool {
+	if names == nil {
+		names = defaultNames
+	}
+
+	set := make(map[ValidatorName]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+
+	return set
+}
+
+// depsGraph returns names of validators, which need to be finished before start of each validator.
+// The graph contains dependencies of validators, DepValidator.After and additional edges of ExecutionPolicy.
+func (d DepValidator) depsGraph() (depsGraph, error) {
+	graph := d.ownGraph()
+	order, err := graph.order()
+	if err != nil {
+		return graph, err
+	}
+
+	switch d.Policy {
+	case StopOnFirstErrorPolicy:
+		for i := 1; i < len(order); i++ {
+			graph[order[i]] = append(graph[order[i]], order[i-1])
+		}
+	case SkipExpensivePolicy:
+		expensive := d.expensive()
+		blocking := d.blocking()
+		for name := range graph {
+			if !expensive[name] {
+				continue
+			}
+			for _, blockingName := range order {
+				if blocking[blockingName] && !expensive[blockingName] {
+					graph[name] = append(graph[name], blockingName)
+				}
+			}
+		}
+	}
+
+	_, err = graph.order()
+
+	return graph, err
+}
+
+// waitGraph returns depsGraph or only dependencies of validators and DepValidator.After if ExecutionPolicy forms a cycle.
+// UnknownDepError or CycleDepError is returned if dependencies of validators are broken.
+func (d DepValidator) waitGraph() (depsGraph, error) {
+	graph, err := d.depsGraph()
+	if err == nil {
+		return graph, nil
+	}
+
+	graph = d.ownGraph()
+	if _, err := graph.order(); err != nil {
+		return nil, err
+	}
+
+	return graph, nil
+}
+
+// ownGraph returns dependencies of validators and validators, which should be finished before them by After
+func (d DepValidator) ownGraph() depsGraph {
+	graph := make(depsGraph, len(d.Deps))
+	for name, validator := range d.Deps {
+		graph[name] = append(append([]ValidatorName{}, validator.GetDeps()...), d.After[name]...)
+	}
+
+	return graph
+}
+
+func (d DepValidator) expensive() map[ValidatorName]bool {
+	return namesSet(d.Expensive, DefaultExpensiveValidators)
+}
+
+func (d DepValidator) blocking() map[ValidatorName]bool {
+	return namesSet(d.Blocking, DefaultBlockingValidators)
+}
+
+// skipReason returns reason to skip validator by ExecutionPolicy, empty string means validator should be run
+func (d DepValidator) skipReason(isExpensive, hasInvalid, hasBlockingInvalid bool) string {
+	switch d.Policy {
+	case StopOnFirstErrorPolicy:
+		if hasInvalid {
+			return SkipReasonFirstError
+		}
+	case SkipExpensivePolicy:
+		if hasBlockingInvalid && isExpensive {
+			return SkipReasonBlockingInvalid
+		}
+	}
+
+	return ""
+}
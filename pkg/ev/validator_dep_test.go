@@ -1,13 +1,18 @@
 package ev_test
 
 import (
+	"context"
+	"errors"
+	"math/rand"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/prodadidb/go-email-validator/pkg/ev"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evtests"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -90,6 +95,203 @@ func TestDepValidator_Validate_Dependent(t *testing.T) {
 	require.True(t, v.IsValid())
 }
 
+func TestDepValidator_ValidateContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), mockCtxKey{}, mockValidatorName)
+
+	depValidator := ev.NewDepValidator(map[ev.ValidatorName]ev.Validator{
+		"test1": mockContextValidator{want: mockValidatorName},
+		"test2": ev.NewWarningsDecorator(
+			mockContextValidator{want: mockValidatorName},
+			func(err error) bool { return false },
+		),
+		"test3": mockContextValidator{
+			mockValidator: mockValidator{deps: []ev.ValidatorName{"test1", "test2"}},
+			want:          mockValidatorName,
+		},
+	})
+
+	v := depValidator.(ev.ContextValidator).ValidateContext(ctx, ev.NewInput(GetValidTestEmail()))
+	require.True(t, v.IsValid())
+
+	v = depValidator.Validate(ev.NewInput(GetValidTestEmail()))
+	require.False(t, v.IsValid())
+}
+
+type panicValidator struct {
+	mockValidator
+}
+
+func (panicValidator) Validate(_ ev.Input, _ ...ev.ValidationResult) ev.ValidationResult {
+	panic(errorSimple)
+}
+
+func TestDepValidator_Validate_Panic(t *testing.T) {
+	depValidator := ev.NewDepValidator(map[ev.ValidatorName]ev.Validator{
+		"panic":       panicValidator{},
+		"independent": newMockValidator(true),
+		"dependent":   panicValidator{mockValidator{deps: []ev.ValidatorName{"panic", "independent"}}},
+		"transitive":  mockValidator{result: true, deps: []ev.ValidatorName{"dependent"}},
+	})
+
+	v := depValidator.Validate(ev.NewInput(GetValidTestEmail()))
+	require.False(t, v.IsValid())
+
+	results := v.(ev.DepValidationResult).GetResults()
+	require.True(t, results["independent"].IsValid())
+
+	var panicErr *ev.PanicError
+	require.ErrorAs(t, results["panic"].Errors()[0], &panicErr)
+	require.Equal(t, ev.ValidatorName("panic"), panicErr.Validator)
+	require.Equal(t, errorSimple, panicErr.Value)
+	require.NotEmpty(t, panicErr.Stack)
+
+	require.Equal(t, ev.NewResultWithOutcome(ev.OutcomeUnknown, utils.Errs(ev.NewDepsError()), nil, "dependent"), results["dependent"])
+	require.Equal(t, ev.NewResultWithOutcome(ev.OutcomeUnknown, utils.Errs(ev.NewDepsError()), nil, "transitive"), results["transitive"])
+}
+
+func TestPanicError_Error(t *testing.T) {
+	err := ev.NewPanicError(mockValidatorName, errorSimple, nil)
+	require.Equal(t, `PanicError: validator "mockValidatorName" panicked: errorSimple`, err.Error())
+}
+
+type notRunValidator struct {
+	mockValidator
+	t *testing.T
+}
+
+func (n notRunValidator) Validate(_ ev.Input, _ ...ev.ValidationResult) ev.ValidationResult {
+	n.t.Errorf("validator should be skipped")
+
+	return ev.NewValidResult(ev.OtherValidator)
+}
+
+func TestDepValidator_Validate_Policy(t *testing.T) {
+	tests := []struct {
+		name        string
+		builder     *ev.DepBuilder
+		wantValid   bool
+		wantResults ev.DepResult
+	}{
+		{
+			name: "run all",
+			builder: ev.NewDepBuilder(ev.ValidatorMap{
+				"a": newMockValidator(true),
+				"b": newMockValidator(false),
+				"c": newMockValidator(true),
+			}),
+			wantValid: false,
+			wantResults: ev.DepResult{
+				"a": validResult,
+				"b": invalidResult,
+				"c": validResult,
+			},
+		},
+		{
+			name: "stop on first error",
+			builder: ev.NewDepBuilder(ev.ValidatorMap{
+				"a": newMockValidator(true),
+				"b": newMockValidator(false),
+				"c": notRunValidator{t: t},
+				"d": notRunValidator{mockValidator{deps: []ev.ValidatorName{"a"}}, t},
+			}).SetPolicy(ev.StopOnFirstErrorPolicy),
+			wantValid: false,
+			wantResults: ev.DepResult{
+				"a": validResult,
+				"b": invalidResult,
+				"c": ev.NewSkippedResult("c", ev.SkipReasonFirstError),
+				"d": ev.NewSkippedResult("d", ev.SkipReasonFirstError),
+			},
+		},
+		{
+			name: "skip expensive",
+			builder: ev.NewDepBuilder(ev.ValidatorMap{
+				ev.SyntaxValidatorName:     newMockValidator(true),
+				ev.DisposableValidatorName: newMockValidator(false),
+				ev.RoleValidatorName:       newMockValidator(true),
+				ev.SMTPValidatorName:       notRunValidator{mockValidator{deps: []ev.ValidatorName{ev.SyntaxValidatorName}}, t},
+				mockValidatorName:          notRunValidator{mockValidator{deps: []ev.ValidatorName{ev.SMTPValidatorName}}, t},
+			}).SetPolicy(ev.SkipExpensivePolicy),
+			wantValid: false,
+			wantResults: ev.DepResult{
+				ev.SyntaxValidatorName:     validResult,
+				ev.DisposableValidatorName: invalidResult,
+				ev.RoleValidatorName:       validResult,
+				ev.SMTPValidatorName:       ev.NewSkippedResult(ev.SMTPValidatorName, ev.SkipReasonBlockingInvalid),
+				mockValidatorName:          ev.NewSkippedResult(mockValidatorName, ev.SkipReasonDepSkipped),
+			},
+		},
+		{
+			name: "skip expensive after not blocking error",
+			builder: ev.NewDepBuilder(ev.ValidatorMap{
+				ev.RoleValidatorName: newMockValidator(false),
+				ev.SMTPValidatorName: newMockValidator(true),
+			}).SetPolicy(ev.SkipExpensivePolicy).SetBlocking(ev.SyntaxValidatorName),
+			wantValid: false,
+			wantResults: ev.DepResult{
+				ev.RoleValidatorName: invalidResult,
+				ev.SMTPValidatorName: validResult,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.builder.Build().Validate(ev.NewInput(GetValidTestEmail()))
+
+			require.Equal(t, tt.wantValid, v.IsValid())
+			require.Equal(t, tt.wantResults, v.(ev.DepValidationResult).GetResults())
+		})
+	}
+}
+
+// sleepingValidator sleeps random time before validation to change order of goroutines
+type sleepingValidator struct {
+	mockValidator
+}
+
+func (s sleepingValidator) Validate(input ev.Input, results ...ev.ValidationResult) ev.ValidationResult {
+	time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+	return s.mockValidator.Validate(input, results...)
+}
+
+func TestDepValidator_Validate_StopOnFirstErrorDeterministic(t *testing.T) {
+	v := ev.NewDepBuilder(ev.ValidatorMap{
+		"a": sleepingValidator{newMockValidator(true)},
+		"b": sleepingValidator{newMockValidator(true)},
+		"c": sleepingValidator{newMockValidator(false)},
+		"d": sleepingValidator{newMockValidator(true)},
+		"e": sleepingValidator{newMockValidator(false)},
+	}).SetPolicy(ev.StopOnFirstErrorPolicy).Build()
+
+	for i := 0; i < 20; i++ {
+		var skipped []ev.ValidatorName
+		for name, result := range v.Validate(ev.NewInput(GetValidTestEmail())).(ev.DepValidationResult).GetResults() {
+			var skippedErr *ev.SkippedError
+			if len(result.Errors()) > 0 && errors.As(result.Errors()[0], &skippedErr) {
+				skipped = append(skipped, name)
+			}
+		}
+		sort.Slice(skipped, func(l, r int) bool { return skipped[l] < skipped[r] })
+
+		require.Equal(t, []ev.ValidatorName{"d", "e"}, skipped)
+	}
+}
+
+func TestExecutionPolicyByName(t *testing.T) {
+	for _, policy := range []ev.ExecutionPolicy{ev.RunAllPolicy, ev.StopOnFirstErrorPolicy, ev.SkipExpensivePolicy} {
+		got, ok := ev.ExecutionPolicyByName(policy.String())
+		require.True(t, ok)
+		require.Equal(t, policy, got)
+	}
+
+	_, ok := ev.ExecutionPolicyByName("unknown")
+	require.False(t, ok)
+}
+
+func TestSkippedError_Error(t *testing.T) {
+	require.Equal(t, ev.SkippedErr, ev.NewSkippedError("").Error())
+	require.Equal(t, ev.SkippedErr+": "+ev.SkipReasonFirstError, ev.NewSkippedError(ev.SkipReasonFirstError).Error())
+}
+
 func TestDepValidator_Validate_Full(t *testing.T) {
 	evtests.FunctionalSkip(t)
 
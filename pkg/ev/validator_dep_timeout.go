@@ -0,0 +1,97 @@
+package ev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+)
+
+// NewTimeoutError creates TimeoutError
+func NewTimeoutError(name ValidatorName) error {
+	return &TimeoutError{Validator: name}
+}
+
+// TimeoutError is error of validator, which was not finished before timeout of validator or DepValidator
+type TimeoutError struct {
+	Validator ValidatorName
+}
+
+func (t *TimeoutError) Error() string {
+	return fmt.Sprintf("TimeoutError: validator %q exceeded deadline", t.Validator)
+}
+
+// Code returns TimeoutCode
+func (t *TimeoutError) Code() string {
+	return TimeoutCode
+}
+
+// Category returns TransientCategory
+func (t *TimeoutError) Category() string {
+	return TransientCategory
+}
+
+// Details returns validator
+func (t *TimeoutError) Details() map[string]interface{} {
+	return map[string]interface{}{"validator": string(t.Validator)}
+}
+
+// Unwrap returns context.DeadlineExceeded
+func (t *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// contextErrResult forms result of validator, which was interrupted by ctx
+func contextErrResult(key ValidatorName, err error) ValidationResult {
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = NewTimeoutError(key)
+	}
+
+	return NewResultWithOutcome(OutcomeUnknown, utils.Errs(err), nil, key)
+}
+
+// timeoutValidate runs safeValidate, validator is abandoned if ctx is done or timeout is exceeded.
+// The goroutine of abandoned validator is not stopped, it runs until the validator returns.
+// ctx of the validator is canceled after return, so ContextValidator should stop on ctx.Done(),
+// e.g. SMTP validator closes its connection. Validator without ContextValidator keeps running,
+// so slow validators must implement it. Results of abandoned validators are not cached by CacheDecorator.
+func timeoutValidate(
+	ctx context.Context,
+	key ValidatorName,
+	timeout time.Duration,
+	validator Validator,
+	input Input,
+	results ...ValidationResult,
+) (ValidationResult, bool) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if ctx.Done() == nil {
+		return safeValidate(ctx, key, validator, input, results...)
+	}
+	if err := ctx.Err(); err != nil {
+		return contextErrResult(key, err), true
+	}
+
+	type safeResult struct {
+		result   ValidationResult
+		panicked bool
+	}
+	done := make(chan safeResult, 1)
+	go func() {
+		result, panicked := safeValidate(ctx, key, validator, input, results...)
+		done <- safeResult{result, panicked}
+	}()
+
+	select {
+	case r := <-done:
+		return r.result, r.panicked
+	case <-ctx.Done():
+		return contextErrResult(key, ctx.Err()), true
+	}
+}
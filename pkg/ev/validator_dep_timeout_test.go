@@ -0,0 +1,150 @@
+package ev_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDepValidator_Validate_Timeout(t *testing.T) {
+	sleepValidator := func(sleep time.Duration, deps ...ev.ValidatorName) ev.Validator {
+		return &testSleep{sleep, newMockValidator(true), deps}
+	}
+
+	tests := []struct {
+		name        string
+		builder     *ev.DepBuilder
+		wantResults map[ev.ValidatorName]error
+	}{
+		{
+			name: "validator timeout",
+			builder: ev.NewDepBuilder(ev.ValidatorMap{
+				"fast":      sleepValidator(0),
+				"slow":      sleepValidator(time.Second),
+				"dependent": sleepValidator(0, "slow"),
+			}).SetTimeout("slow", 10*time.Millisecond),
+			wantResults: map[ev.ValidatorName]error{
+				"fast":      nil,
+				"slow":      ev.NewTimeoutError("slow"),
+				"dependent": ev.NewDepsError(),
+			},
+		},
+		{
+			name: "total timeout",
+			builder: ev.NewDepBuilder(ev.ValidatorMap{
+				"first":  sleepValidator(30 * time.Millisecond),
+				"second": sleepValidator(time.Second, "first"),
+				"third":  sleepValidator(0, "second"),
+			}).SetTotalTimeout(50 * time.Millisecond),
+			wantResults: map[ev.ValidatorName]error{
+				"first":  nil,
+				"second": ev.NewTimeoutError("second"),
+				"third":  ev.NewDepsError(),
+			},
+		},
+		{
+			name: "validator timeout is not exceeded",
+			builder: ev.NewDepBuilder(ev.ValidatorMap{
+				"fast": sleepValidator(0),
+			}).SetTimeout("fast", time.Second).SetTotalTimeout(time.Second),
+			wantResults: map[ev.ValidatorName]error{
+				"fast": nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			v := tt.builder.Build().Validate(ev.NewInput(GetValidTestEmail()))
+			require.Less(t, time.Since(start), time.Second)
+
+			results := v.(ev.DepValidationResult).GetResults()
+			require.Len(t, results, len(tt.wantResults))
+			for name, wantErr := range tt.wantResults {
+				if wantErr == nil {
+					require.True(t, results[name].IsValid(), name)
+					continue
+				}
+				require.Equal(t, ev.NewResultWithOutcome(ev.OutcomeUnknown, utils.Errs(wantErr), nil, name), results[name])
+			}
+		})
+	}
+}
+
+func TestDepValidator_ValidateContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	v := ev.NewDepValidator(ev.ValidatorMap{mockValidatorName: newMockValidator(true)})
+	got := v.(ev.ContextValidator).ValidateContext(ctx, ev.NewInput(GetValidTestEmail()))
+
+	require.Equal(t, ev.DepResult{
+		mockValidatorName: ev.NewResultWithOutcome(ev.OutcomeUnknown, utils.Errs(context.Canceled), nil, mockValidatorName),
+	}, got.(ev.DepValidationResult).GetResults())
+}
+
+// waitContextValidator returns result with error of ctx after ctx is done like SMTP validator
+type waitContextValidator struct {
+	ev.AValidatorWithoutDeps
+}
+
+func (w waitContextValidator) Validate(input ev.Input, results ...ev.ValidationResult) ev.ValidationResult {
+	return w.ValidateContext(context.Background(), input, results...)
+}
+
+func (w waitContextValidator) ValidateContext(ctx context.Context, _ ev.Input, _ ...ev.ValidationResult) ev.ValidationResult {
+	<-ctx.Done()
+
+	return ev.NewResultWithOutcome(ev.OutcomeUnknown, utils.Errs(ctx.Err()), nil, mockValidatorName)
+}
+
+// doneValidator closes done after validator returns
+type doneValidator struct {
+	ev.Validator
+	done chan struct{}
+}
+
+func (d doneValidator) ValidateContext(ctx context.Context, input ev.Input, results ...ev.ValidationResult) ev.ValidationResult {
+	defer close(d.done)
+
+	return ev.ValidateContext(ctx, d.Validator, input, results...)
+}
+
+func TestDepValidator_Validate_TimeoutCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	input := ev.NewInput(GetValidTestEmail())
+	cacheMock := NewMockInterface(ctrl)
+	cacheMock.EXPECT().Get(gomock.Any(), input.Email().String()).Return(nil, nil).Times(1)
+
+	done := make(chan struct{})
+	validator := doneValidator{ev.NewCacheDecorator(waitContextValidator{}, cacheMock, nil), done}
+	got := ev.NewDepBuilder(ev.ValidatorMap{mockValidatorName: validator}).
+		SetTimeout(mockValidatorName, 10*time.Millisecond).
+		Build().Validate(input)
+
+	require.Equal(t, ev.DepResult{
+		mockValidatorName: ev.NewResultWithOutcome(ev.OutcomeUnknown, utils.Errs(ev.NewTimeoutError(mockValidatorName)), nil, mockValidatorName),
+	}, got.(ev.DepValidationResult).GetResults())
+
+	// ctx of abandoned validator is canceled, so it returns and its result is not cached
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("abandoned validator is not stopped")
+	}
+}
+
+func TestTimeoutError(t *testing.T) {
+	err := ev.NewTimeoutError(mockValidatorName)
+
+	require.Equal(t, `TimeoutError: validator "mockValidatorName" exceeded deadline`, err.Error())
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -0,0 +1,91 @@
+package ev
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+)
+
+// TraceStatus is status of validator in Trace
+type TraceStatus string
+
+// Statuses of validators
+const (
+	TraceValid     TraceStatus = "valid"
+	TraceInvalid   TraceStatus = "invalid"
+	TraceUnknown   TraceStatus = "unknown"
+	TraceSkipped   TraceStatus = "skipped"
+	TraceTimeout   TraceStatus = "timeout"
+	TraceCanceled  TraceStatus = "canceled"
+	TracePanic     TraceStatus = "panic"
+	TraceDepsError TraceStatus = "depsError"
+)
+
+// ValidatorTrace is execution trace of one validator in DepValidator
+type ValidatorTrace struct {
+	Validator ValidatorName `json:"validator"`
+	Start     time.Time     `json:"start"`
+	Duration  time.Duration `json:"duration"`
+	Status    TraceStatus   `json:"status"`
+	// Reason is reason of skipping, timeout or panic
+	Reason string `json:"reason,omitempty"`
+	// Stages are timings of SMTP stages, if validator used evsmtp.CheckerStruct
+	Stages []evsmtp.StageTiming `json:"stages,omitempty"`
+}
+
+// Trace is execution trace of DepValidator
+type Trace struct {
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	// Validators are sorted by start time
+	Validators []ValidatorTrace `json:"validators"`
+}
+
+// Validator returns trace of validator by name
+func (t Trace) Validator(name ValidatorName) (ValidatorTrace, bool) {
+	for _, validatorTrace := range t.Validators {
+		if validatorTrace.Validator == name {
+			return validatorTrace, true
+		}
+	}
+
+	return ValidatorTrace{}, false
+}
+
+func (t *Trace) sort() {
+	sort.SliceStable(t.Validators, func(i, j int) bool {
+		if t.Validators[i].Start.Equal(t.Validators[j].Start) {
+			return t.Validators[i].Validator < t.Validators[j].Validator
+		}
+
+		return t.Validators[i].Start.Before(t.Validators[j].Start)
+	})
+}
+
+// traceStatus returns status and reason of result of run validator
+func traceStatus(result ValidationResult) (TraceStatus, string) {
+	for _, err := range result.Errors() {
+		var timeoutErr *TimeoutError
+		var panicErr *PanicError
+		switch {
+		case errors.As(err, &timeoutErr):
+			return TraceTimeout, err.Error()
+		case errors.As(err, &panicErr):
+			return TracePanic, err.Error()
+		case errors.Is(err, context.Canceled):
+			return TraceCanceled, err.Error()
+		}
+	}
+
+	switch result.Outcome() {
+	case OutcomeValid:
+		return TraceValid, ""
+	case OutcomeUnknown:
+		return TraceUnknown, ""
+	}
+
+	return TraceInvalid, ""
+}
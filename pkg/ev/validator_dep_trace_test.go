@@ -0,0 +1,127 @@
+package ev_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/stretchr/testify/require"
+)
+
+type stagesValidator struct {
+	mockValidator
+}
+
+func (s stagesValidator) ValidateContext(ctx context.Context, input ev.Input, results ...ev.ValidationResult) ev.ValidationResult {
+	trace := evsmtp.StagesTraceFromContext(ctx)
+	trace.Start(evsmtp.HelloStage)
+	trace.Start(evsmtp.QuitStage)
+
+	return s.Validate(input, results...)
+}
+
+func TestDepValidator_Validate_Trace(t *testing.T) {
+	validator := ev.NewDepBuilder(ev.ValidatorMap{
+		ev.SyntaxValidatorName: newMockValidator(false),
+		ev.SMTPValidatorName:   stagesValidator{newMockValidator(true)},
+		"dependent":            mockValidator{result: true, deps: []ev.ValidatorName{"panic"}},
+		"panic":                panicValidator{},
+		"slow":                 &testSleep{time.Second, newMockValidator(true), nil},
+	}).SetTimeout("slow", 10*time.Millisecond).Build()
+
+	result := validator.Validate(ev.NewInput(validEmail))
+	trace := result.(ev.DepValidationResult).GetTrace()
+
+	tests := []struct {
+		name       ev.ValidatorName
+		wantStatus ev.TraceStatus
+		wantReason string
+		wantStages []string
+	}{
+		{name: ev.SyntaxValidatorName, wantStatus: ev.TraceInvalid},
+		{name: ev.SMTPValidatorName, wantStatus: ev.TraceValid, wantStages: []string{"hello", "quit"}},
+		{name: "panic", wantStatus: ev.TracePanic},
+		{name: "dependent", wantStatus: ev.TraceDepsError},
+		{name: "slow", wantStatus: ev.TraceTimeout, wantReason: ev.NewTimeoutError("slow").Error()},
+	}
+	require.Len(t, trace.Validators, len(tests))
+	for _, tt := range tests {
+		t.Run(string(tt.name), func(t *testing.T) {
+			got, ok := trace.Validator(tt.name)
+			require.True(t, ok)
+			require.Equal(t, tt.wantStatus, got.Status)
+			if tt.wantReason != "" {
+				require.Equal(t, tt.wantReason, got.Reason)
+			}
+			require.False(t, got.Start.Before(trace.Start))
+			require.LessOrEqual(t, got.Duration, trace.Duration)
+
+			stages := make([]string, 0)
+			for _, stage := range got.Stages {
+				stages = append(stages, stage.Name)
+			}
+			if tt.wantStages == nil {
+				tt.wantStages = []string{}
+			}
+			require.Equal(t, tt.wantStages, stages)
+		})
+	}
+
+	for i := 1; i < len(trace.Validators); i++ {
+		require.False(t, trace.Validators[i].Start.Before(trace.Validators[i-1].Start))
+	}
+}
+
+func TestDepValidator_Validate_TraceSkipped(t *testing.T) {
+	validator := ev.NewDepBuilder(ev.ValidatorMap{
+		ev.SyntaxValidatorName: newMockValidator(false),
+		ev.SMTPValidatorName:   newMockValidator(true),
+	}).SetPolicy(ev.SkipExpensivePolicy).Build()
+
+	result := validator.Validate(ev.NewInput(validEmail))
+	got, ok := result.(ev.DepValidationResult).GetTrace().Validator(ev.SMTPValidatorName)
+
+	require.True(t, ok)
+	require.Equal(t, ev.TraceSkipped, got.Status)
+	require.Equal(t, ev.SkipReasonBlockingInvalid, got.Reason)
+}
+
+func TestTrace_JSON(t *testing.T) {
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	trace := ev.Trace{
+		Start:    start,
+		Duration: time.Second,
+		Validators: []ev.ValidatorTrace{
+			{
+				Validator: ev.SMTPValidatorName,
+				Start:     start,
+				Duration:  time.Millisecond,
+				Status:    ev.TraceValid,
+				Stages: []evsmtp.StageTiming{
+					{
+						Stage:    evsmtp.HelloStage,
+						Name:     evsmtp.StageName(evsmtp.HelloStage),
+						Start:    start,
+						Duration: time.Microsecond,
+					},
+				},
+			},
+			{
+				Validator: ev.GravatarValidatorName,
+				Start:     start,
+				Status:    ev.TraceSkipped,
+				Reason:    ev.SkipReasonBlockingInvalid,
+			},
+		},
+	}
+
+	data, err := json.Marshal(trace)
+	require.NoError(t, err)
+
+	var got ev.Trace
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, trace, got)
+}
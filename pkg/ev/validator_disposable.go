@@ -18,6 +18,21 @@ func (DisposableError) Error() string {
 	return DisposableErr
 }
 
+// Code returns DisposableCode
+func (DisposableError) Code() string {
+	return DisposableCode
+}
+
+// Category returns PolicyCategory
+func (DisposableError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns nil
+func (DisposableError) Details() map[string]interface{} {
+	return nil
+}
+
 // NewDisposableValidator instantiates DisposableValidatorName
 func NewDisposableValidator(d contains.InSet) Validator {
 	return disposableValidator{d: d}
@@ -19,6 +19,21 @@ func (FreeError) Error() string {
 	return FreeErr
 }
 
+// Code returns FreeCode
+func (FreeError) Code() string {
+	return FreeCode
+}
+
+// Category returns PolicyCategory
+func (FreeError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns nil
+func (FreeError) Details() map[string]interface{} {
+	return nil
+}
+
 // FreeDefaultValidator instantiates default FreeValidatorName based on free.NewWillWhiteSetFree()
 func FreeDefaultValidator() Validator {
 	return NewFreeValidator(free.NewWillWhiteSetFree())
@@ -1,12 +1,14 @@
 package ev
 
 import (
+	"context"
 	"crypto/md5" //nolint:gosec
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/vmihailenco/msgpack"
 )
 
 const (
@@ -49,6 +51,21 @@ func (GravatarError) Error() string {
 	return GravatarErr
 }
 
+// Code returns GravatarCode
+func (GravatarError) Code() string {
+	return GravatarCode
+}
+
+// Category returns MailboxCategory
+func (GravatarError) Category() string {
+	return MailboxCategory
+}
+
+// Details returns nil
+func (GravatarError) Details() map[string]interface{} {
+	return nil
+}
+
 // NewGravatarValidator instantiates GravatarValidatorName validator with GravatarURL for validation
 func NewGravatarValidator() Validator {
 	return NewGravatarValidatorWithURL(GravatarURL)
@@ -73,6 +90,10 @@ func (g gravatarValidator) GetDeps() []ValidatorName {
 }
 
 func (g gravatarValidator) Validate(input Input, results ...ValidationResult) ValidationResult {
+	return g.ValidateContext(context.Background(), input, results...)
+}
+
+func (g gravatarValidator) ValidateContext(ctx context.Context, input Input, results ...ValidationResult) ValidationResult {
 	syntaxResult := results[0].(SyntaxValidatorResult)
 	if !syntaxResult.IsValid() {
 		return GravatarGetError(NewDepsError())
@@ -86,14 +107,21 @@ func (g gravatarValidator) Validate(input Input, results ...ValidationResult) Va
 	client := &http.Client{Timeout: opts.Timeout()}
 
 	gravatarURL := fmt.Sprintf(
-		GravatarURL,
+		g.url,
 		g.h(input.Email().String()),
 	)
-	resp, err := client.Head(gravatarURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, gravatarURL, nil)
 	if err != nil {
 		return GravatarGetError(err)
 	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return GravatarGetUnknown(err)
+	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return GravatarGetUnknown(GravatarError{})
+	}
 	if resp.StatusCode != 200 {
 		return GravatarGetError(GravatarError{})
 	}
@@ -111,6 +139,14 @@ func GravatarGetError(err error) ValidationResult {
 	)
 }
 
+// GravatarGetUnknown returns unknown result with transient err
+func GravatarGetUnknown(err error) ValidationResult {
+	return NewGravatarValidationResult(
+		"",
+		NewResultWithOutcome(OutcomeUnknown, utils.Errs(err), nil, GravatarValidatorName).(*AValidationResult),
+	)
+}
+
 type gravatarValidationResult struct {
 	*AValidationResult
 	url string
@@ -120,6 +156,20 @@ func (v gravatarValidationResult) URL() string {
 	return v.url
 }
 
+// EncodeMsgpack implements encoder for msgpack, it keeps URL in cache
+func (v gravatarValidationResult) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeMulti(v.AValidationResult, v.url)
+}
+
+// DecodeMsgpack implements decoder for msgpack
+func (v *gravatarValidationResult) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return dec.DecodeMulti(&v.AValidationResult, &v.url)
+}
+
+func (v *gravatarValidationResult) value() ValidationResult {
+	return *v
+}
+
 // GravatarOptions describes gravatar options
 type GravatarOptions interface {
 	Timeout() time.Duration
@@ -1,6 +1,7 @@
 package ev_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
@@ -10,6 +11,7 @@ import (
 	"github.com/prodadidb/go-email-validator/pkg/ev"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evtests"
+	"github.com/stretchr/testify/require"
 )
 
 const GravatarExistEmail = "beau@dentedreality.com.au"
@@ -67,7 +69,7 @@ func Test_gravatarValidator_Validate(t *testing.T) {
 					ev.NewGravatarOptions(ev.GravatarOptionsDTO{Timeout: 1}),
 				)},
 			},
-			want: ev.GravatarGetError(&url.Error{
+			want: ev.GravatarGetUnknown(&url.Error{
 				Op:  "Head",
 				URL: "https://www.gravatar.com/avatar/77996abfe12fc2141488a60b29aa4844?d=404",
 				Err: errors.New("context deadline exceeded (Client.Timeout exceeded while awaiting headers)"),
@@ -132,6 +134,22 @@ func Test_gravatarValidator_race_parallel(t *testing.T) {
 	}
 }
 
+func Test_gravatarValidator_ValidateContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	w := ev.NewGravatarValidator()
+	gotInterface := w.(ev.ContextValidator).ValidateContext(
+		ctx,
+		ev.NewInput(evmail.FromString(GravatarExistEmail)),
+		ev.NewValidResult(ev.SyntaxValidatorName),
+	)
+
+	got := gotInterface.(ev.GravatarValidationResult)
+	require.False(t, got.IsValid())
+	require.ErrorIs(t, got.Errors()[0], context.Canceled)
+}
+
 func Test_gravatarValidator_GetDeps(t *testing.T) {
 	tests := []struct {
 		name string
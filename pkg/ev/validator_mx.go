@@ -1,8 +1,13 @@
 package ev
 
 import (
+	"context"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evcache"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
 	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/vmihailenco/msgpack"
 )
 
 // MXValidatorName  is name of mx validator
@@ -15,6 +20,21 @@ func (EmptyMXsError) Error() string {
 	return "EmptyMXsError"
 }
 
+// Code returns EmptyMXsCode
+func (EmptyMXsError) Code() string {
+	return EmptyMXsCode
+}
+
+// Category returns DomainCategory
+func (EmptyMXsError) Category() string {
+	return DomainCategory
+}
+
+// Details returns nil
+func (EmptyMXsError) Details() map[string]interface{} {
+	return nil
+}
+
 // MXValidationResult is result of MXValidatorName
 type MXValidationResult interface {
 	MX() evsmtp.MXs
@@ -35,34 +55,75 @@ func (v mxValidationResult) MX() evsmtp.MXs {
 	return v.mx
 }
 
-// DefaultNewMXValidator instantiates default MXValidatorName based on evsmtp.LookupMX
+// EncodeMsgpack implements encoder for msgpack, it keeps MX in cache
+func (v mxValidationResult) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeMulti(v.AValidationResult, v.mx)
+}
+
+// DecodeMsgpack implements decoder for msgpack
+func (v *mxValidationResult) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return dec.DecodeMulti(&v.AValidationResult, &v.mx)
+}
+
+func (v *mxValidationResult) value() ValidationResult {
+	return *v
+}
+
+// DefaultNewMXValidator instantiates default MXValidatorName based on evsmtp.LookupMXContext
 func DefaultNewMXValidator() Validator {
-	return NewMXValidator(evsmtp.LookupMX)
+	return NewMXValidatorContext(evsmtp.LookupMXContext)
 }
 
 // NewMXValidator instantiates MXValidatorName based on evsmtp.FuncLookupMX
 func NewMXValidator(lookupMX evsmtp.FuncLookupMX) Validator {
+	return NewMXValidatorContext(evsmtp.LookupMXWithContext(lookupMX))
+}
+
+// NewMXValidatorContext instantiates MXValidatorName based on evsmtp.FuncLookupMXContext
+func NewMXValidatorContext(lookupMX evsmtp.FuncLookupMXContext) Validator {
 	return mxValidator{
 		lookupMX: lookupMX,
 	}
 }
 
+// mxMemoKey is key of evcache.Memo for MX lookups by domain
+type mxMemoKey string
+
 type mxValidator struct {
 	AValidatorWithoutDeps
-	lookupMX evsmtp.FuncLookupMX
+	lookupMX evsmtp.FuncLookupMXContext
 }
 
-func (v mxValidator) Validate(input Input, _ ...ValidationResult) ValidationResult {
+func (v mxValidator) Validate(input Input, results ...ValidationResult) ValidationResult {
+	return v.ValidateContext(context.Background(), input, results...)
+}
+
+func (v mxValidator) ValidateContext(ctx context.Context, input Input, _ ...ValidationResult) ValidationResult {
 	var mxs evsmtp.MXs
 	var err error
-	mxs, err = v.lookupMX(input.Email().Domain())
+	// internationalized domains are looked up by ASCII form
+	domain := evmail.ASCIIDomain(input.Email())
+	if memo := evcache.MemoFromContext(ctx); memo != nil {
+		var value interface{}
+		value, err = memo.Do(ctx, mxMemoKey(domain), func(ctx context.Context) (interface{}, error) {
+			return v.lookupMX(ctx, domain)
+		})
+		mxs, _ = value.(evsmtp.MXs)
+	} else {
+		mxs, err = v.lookupMX(ctx, domain)
+	}
 
 	if hasMXs := len(mxs) > 0; err == nil && !hasMXs {
 		err = EmptyMXsError{}
 	}
 
+	outcome := OutcomeOf(err == nil)
+	if evsmtp.IsTransient(err) {
+		outcome = OutcomeUnknown
+	}
+
 	return NewMXValidationResult(
 		mxs,
-		NewResult(err == nil, utils.Errs(err), nil, MXValidatorName).(*AValidationResult),
+		NewResultWithOutcome(outcome, utils.Errs(err), nil, MXValidatorName).(*AValidationResult),
 	)
 }
@@ -1,11 +1,14 @@
 package ev_test
 
 import (
+	"context"
 	"net"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evcache"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
 	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
@@ -99,6 +102,38 @@ func Test_mxValidator_Validate(t *testing.T) {
 	}
 }
 
+func Test_mxValidator_ValidateContext(t *testing.T) {
+	mxs := evsmtp.MXs{&net.MX{}}
+	ctx := context.WithValue(context.Background(), mockCtxKey{}, mockValidatorName)
+
+	v := ev.NewMXValidatorContext(func(gotCtx context.Context, domain string) (evsmtp.MXs, error) {
+		require.Equal(t, ctx, gotCtx)
+		require.Equal(t, validEmail.Domain(), domain)
+
+		return mxs, nil
+	})
+
+	got := v.(ev.ContextValidator).ValidateContext(ctx, ev.NewInput(validEmail))
+	want := ev.NewMXValidationResult(
+		mxs,
+		ev.NewResult(true, nil, nil, ev.MXValidatorName).(*ev.AValidationResult),
+	)
+	require.Equal(t, want, got)
+}
+
+func Test_mxValidator_ValidateContext_IDN(t *testing.T) {
+	mxs := evsmtp.MXs{&net.MX{}}
+
+	v := ev.NewMXValidatorContext(func(_ context.Context, domain string) (evsmtp.MXs, error) {
+		require.Equal(t, "xn--bcher-kva.de", domain)
+
+		return mxs, nil
+	})
+
+	got := v.(ev.ContextValidator).ValidateContext(context.Background(), ev.NewInput(evmail.FromString("josé@bücher.de")))
+	require.True(t, got.IsValid())
+}
+
 func BenchmarkSMTPValidator_Validate_MX(b *testing.B) {
 	email := evmail.FromString(ValidEmailString)
 
@@ -114,3 +149,28 @@ func BenchmarkSMTPValidator_Validate_MX(b *testing.B) {
 		depValidator.Validate(ev.NewInput(email))
 	}
 }
+
+func Test_mxValidator_ValidateContext_Memo(t *testing.T) {
+	calls := 0
+	lookupMX := func(ctx context.Context, domain string) (evsmtp.MXs, error) {
+		calls++
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("shared lookup should not depend on deadline of caller")
+		}
+		if calls == 1 {
+			return nil, &net.DNSError{Err: "server misbehaving", Name: domain, IsTemporary: true}
+		}
+
+		return evsmtp.MXs{{Host: "mx." + domain}}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(evcache.ContextWithMemo(context.Background(), evcache.NewMemo(evcache.MemoDTO{Forget: evsmtp.IsTransient})), time.Minute)
+	defer cancel()
+	validator := ev.NewMXValidatorContext(lookupMX).(ev.ContextValidator)
+	input := ev.NewInput(evmail.FromString("user@example.com"))
+
+	require.Equal(t, ev.OutcomeUnknown, validator.ValidateContext(ctx, input).Outcome())
+	require.True(t, validator.ValidateContext(ctx, input).IsValid(), "temporary errors are not stored")
+	require.True(t, validator.ValidateContext(ctx, input).IsValid())
+	require.Equal(t, 2, calls)
+}
@@ -0,0 +1,76 @@
+package ev
+
+import "fmt"
+
+// Outcome is tri-state status of validation
+type Outcome uint8
+
+// Outcomes of validation
+const (
+	// OutcomeInvalid means, that email is definitely invalid
+	OutcomeInvalid Outcome = iota
+	// OutcomeValid means, that email is valid
+	OutcomeValid
+	// OutcomeUnknown means, that validation failed because of transient errors (DNS failures, timeouts, greylisting)
+	OutcomeUnknown
+)
+
+var outcomeNames = map[Outcome]string{
+	OutcomeInvalid: "invalid",
+	OutcomeValid:   "valid",
+	OutcomeUnknown: "unknown",
+}
+
+func (o Outcome) String() string {
+	return outcomeNames[o]
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (o Outcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (o *Outcome) UnmarshalText(text []byte) error {
+	for outcome, name := range outcomeNames {
+		if name == string(text) {
+			*o = outcome
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown outcome %q", text)
+}
+
+// OutcomeOf converts bool status of validation to Outcome
+func OutcomeOf(isValid bool) Outcome {
+	if isValid {
+		return OutcomeValid
+	}
+
+	return OutcomeInvalid
+}
+
+// NewResultWithOutcome returns result of validation by outcome, IsValid is true only for OutcomeValid
+func NewResultWithOutcome(outcome Outcome, errors []error, warnings []error, name ValidatorName) ValidationResult {
+	result := NewResult(outcome == OutcomeValid, errors, warnings, name).(*AValidationResult)
+	result.UnknownVal = outcome == OutcomeUnknown
+
+	return result
+}
+
+// depsOutcome returns OutcomeInvalid if any of results is invalid, OutcomeUnknown if any of results is unknown,
+// otherwise OutcomeValid
+func depsOutcome(results ...ValidationResult) Outcome {
+	outcome := OutcomeValid
+	for _, result := range results {
+		switch result.Outcome() {
+		case OutcomeInvalid:
+			return OutcomeInvalid
+		case OutcomeUnknown:
+			outcome = OutcomeUnknown
+		}
+	}
+
+	return outcome
+}
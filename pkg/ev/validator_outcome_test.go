@@ -0,0 +1,252 @@
+package ev_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/stretchr/testify/require"
+)
+
+type mockChecker struct {
+	errs []error
+}
+
+func (m mockChecker) Validate(_ evsmtp.MXs, _ evsmtp.Input) []error {
+	return m.errs
+}
+
+func TestOutcome_String(t *testing.T) {
+	require.Equal(t, "valid", ev.OutcomeValid.String())
+	require.Equal(t, "invalid", ev.OutcomeInvalid.String())
+	require.Equal(t, "unknown", ev.OutcomeUnknown.String())
+}
+
+func TestNewResultWithOutcome(t *testing.T) {
+	tests := []struct {
+		outcome     ev.Outcome
+		wantIsValid bool
+	}{
+		{outcome: ev.OutcomeValid, wantIsValid: true},
+		{outcome: ev.OutcomeInvalid, wantIsValid: false},
+		{outcome: ev.OutcomeUnknown, wantIsValid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.outcome.String(), func(t *testing.T) {
+			got := ev.NewResultWithOutcome(tt.outcome, nil, nil, mockValidatorName)
+			require.Equal(t, tt.outcome, got.Outcome())
+			require.Equal(t, tt.wantIsValid, got.IsValid())
+		})
+	}
+}
+
+func TestOutcomeOf(t *testing.T) {
+	require.Equal(t, ev.OutcomeValid, ev.NewValidResult(mockValidatorName).Outcome())
+	require.Equal(t, ev.OutcomeInvalid, ev.NewResult(false, nil, nil, mockValidatorName).Outcome())
+}
+
+func TestDepValidationResult_Outcome(t *testing.T) {
+	unknownResult := ev.NewResultWithOutcome(ev.OutcomeUnknown, nil, nil, mockValidatorName)
+
+	tests := []struct {
+		name   string
+		result ev.ValidationResult
+		want   ev.Outcome
+	}{
+		{
+			name:   "valid",
+			result: ev.NewDepValidatorResult(true, ev.DepResult{"a": validResult}),
+			want:   ev.OutcomeValid,
+		},
+		{
+			name:   "invalid",
+			result: ev.NewDepValidatorResult(false, ev.DepResult{"a": invalidResult, "b": unknownResult}),
+			want:   ev.OutcomeInvalid,
+		},
+		{
+			name:   "unknown",
+			result: ev.NewDepValidatorResult(false, ev.DepResult{"a": validResult, "b": unknownResult}),
+			want:   ev.OutcomeUnknown,
+		},
+		{
+			name:   "invalid without results",
+			result: ev.NewDepValidatorResult(false, nil),
+			want:   ev.OutcomeInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.result.Outcome())
+		})
+	}
+}
+
+func TestMXValidator_Validate_Outcome(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ev.Outcome
+	}{
+		{
+			name: "not found",
+			err:  &net.DNSError{Err: "no such host", IsNotFound: true},
+			want: ev.OutcomeInvalid,
+		},
+		{
+			name: "temporary",
+			err:  &net.DNSError{Err: "server misbehaving", IsTemporary: true},
+			want: ev.OutcomeUnknown,
+		},
+		{
+			name: "timeout",
+			err:  &net.DNSError{Err: "i/o timeout", IsTimeout: true},
+			want: ev.OutcomeUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := ev.NewMXValidator(mockLookupMX(t, validEmail.Domain(), nil, tt.err))
+			require.Equal(t, tt.want, v.Validate(ev.NewInput(validEmail)).Outcome())
+		})
+	}
+}
+
+func TestSMTPValidator_Validate_Outcome(t *testing.T) {
+	results := []ev.ValidationResult{
+		ev.NewValidResult(ev.SyntaxValidatorName),
+		ev.NewMXValidationResult(
+			evsmtp.MXs{&net.MX{}},
+			ev.NewValidResult(ev.MXValidatorName).(*ev.AValidationResult),
+		),
+	}
+	greylisted := &textproto.Error{Code: 450, Msg: "greylisted"}
+	rejected := &textproto.Error{Code: 550, Msg: "user unknown"}
+
+	tests := []struct {
+		name    string
+		errs    []error
+		results []ev.ValidationResult
+		want    ev.Outcome
+	}{
+		{
+			name:    "valid",
+			results: results,
+			want:    ev.OutcomeValid,
+		},
+		{
+			name:    "rejected mailbox",
+			errs:    utils.Errs(evsmtp.NewError(evsmtp.RCPTsStage, rejected)),
+			results: results,
+			want:    ev.OutcomeInvalid,
+		},
+		{
+			name:    "greylisted mailbox",
+			errs:    utils.Errs(evsmtp.NewError(evsmtp.RCPTsStage, greylisted)),
+			results: results,
+			want:    ev.OutcomeUnknown,
+		},
+		{
+			name: "rejected mailbox and greylisted random rcpt",
+			errs: utils.Errs(
+				evsmtp.NewError(evsmtp.RandomRCPTStage, greylisted),
+				evsmtp.NewError(evsmtp.RCPTsStage, rejected),
+			),
+			results: results,
+			want:    ev.OutcomeInvalid,
+		},
+		{
+			name:    "connection",
+			errs:    utils.Errs(evsmtp.ErrConnection),
+			results: results,
+			want:    ev.OutcomeUnknown,
+		},
+		{
+			name:    "timeout",
+			errs:    utils.Errs(evsmtp.NewError(evsmtp.HelloStage, context.DeadlineExceeded)),
+			results: results,
+			want:    ev.OutcomeUnknown,
+		},
+		{
+			name:    "rejected sender",
+			errs:    utils.Errs(evsmtp.NewError(evsmtp.MailStage, &textproto.Error{Code: 550, Msg: "sender rejected"})),
+			results: results,
+			want:    ev.OutcomeUnknown,
+		},
+		{
+			name:    "rejected hello",
+			errs:    utils.Errs(evsmtp.NewError(evsmtp.HelloStage, &textproto.Error{Code: 554, Msg: "go away"})),
+			results: results,
+			want:    ev.OutcomeUnknown,
+		},
+		{
+			name:    "server without SMTPUTF8",
+			errs:    utils.Errs(evsmtp.NewError(evsmtp.MailStage, &evsmtp.SMTPUTF8Error{Mailbox: "josé@domain.com"})),
+			results: results,
+			want:    ev.OutcomeUnknown,
+		},
+		{
+			name:    "circuit open",
+			errs:    utils.Errs(evsmtp.NewError(evsmtp.CircuitOpenStage, &evsmtp.CircuitOpenError{Hosts: []string{"mx.domain.com"}})),
+			results: results,
+			want:    ev.OutcomeUnknown,
+		},
+		{
+			name: "rejected mailbox and sender",
+			errs: utils.Errs(
+				evsmtp.NewError(evsmtp.MailStage, &textproto.Error{Code: 550, Msg: "sender rejected"}),
+				evsmtp.NewError(evsmtp.RCPTsStage, rejected),
+			),
+			results: results,
+			want:    ev.OutcomeInvalid,
+		},
+		{
+			name:    "unknown mx",
+			results: []ev.ValidationResult{results[0], ev.NewMXValidationResult(nil, ev.NewResultWithOutcome(ev.OutcomeUnknown, nil, nil, ev.MXValidatorName).(*ev.AValidationResult))},
+			want:    ev.OutcomeUnknown,
+		},
+		{
+			name:    "invalid mx",
+			results: []ev.ValidationResult{results[0], ev.NewMXValidationResult(nil, ev.NewResult(false, nil, nil, ev.MXValidatorName).(*ev.AValidationResult))},
+			want:    ev.OutcomeInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := ev.NewSMTPValidator(mockChecker{errs: tt.errs})
+			got := v.Validate(ev.NewInput(validEmail), tt.results...)
+			require.Equal(t, tt.want, got.Outcome())
+			require.Equal(t, tt.want == ev.OutcomeValid, got.IsValid())
+		})
+	}
+}
+
+func TestGravatarValidator_Validate_Outcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   ev.Outcome
+	}{
+		{name: "found", status: http.StatusOK, want: ev.OutcomeValid},
+		{name: "not found", status: http.StatusNotFound, want: ev.OutcomeInvalid},
+		{name: "too many requests", status: http.StatusTooManyRequests, want: ev.OutcomeUnknown},
+		{name: "server error", status: http.StatusServiceUnavailable, want: ev.OutcomeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			v := ev.NewGravatarValidatorWithURL(server.URL + "/%x")
+			got := v.Validate(ev.NewInput(validEmail), ev.NewValidResult(ev.SyntaxValidatorName))
+			require.Equal(t, tt.want, got.Outcome())
+		})
+	}
+}
@@ -18,6 +18,21 @@ func (RoleError) Error() string {
 	return RoleErr
 }
 
+// Code returns RoleCode
+func (RoleError) Code() string {
+	return RoleCode
+}
+
+// Category returns PolicyCategory
+func (RoleError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns nil
+func (RoleError) Details() map[string]interface{} {
+	return nil
+}
+
 // NewRoleValidator instantiates RoleValidatorName
 func NewRoleValidator(r contains.InSet) Validator {
 	return roleValidator{r: r}
@@ -1,6 +1,9 @@
 package ev
 
 import (
+	"context"
+	"errors"
+
 	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
 )
 
@@ -21,9 +24,14 @@ func (s smtpValidator) GetDeps() []ValidatorName {
 }
 
 func (s smtpValidator) Validate(input Input, results ...ValidationResult) ValidationResult {
+	return s.ValidateContext(context.Background(), input, results...)
+}
+
+func (s smtpValidator) ValidateContext(ctx context.Context, input Input, results ...ValidationResult) ValidationResult {
 	syntaxResult := results[0].(SyntaxValidatorResult)
 	mxResult := results[1].(MXValidationResult)
 	var errs []error
+	var outcome Outcome
 
 	if syntaxResult.IsValid() && mxResult.IsValid() {
 		var opts evsmtp.Options
@@ -31,13 +39,35 @@ func (s smtpValidator) Validate(input Input, results ...ValidationResult) Valida
 			opts = optsInterface.(evsmtp.Options)
 		}
 
-		errs = s.checker.Validate(
+		errs = evsmtp.ValidateContext(
+			ctx,
+			s.checker,
 			mxResult.MX(),
 			evsmtp.NewInput(input.Email(), opts),
 		)
+		outcome = smtpOutcome(errs)
 	} else {
 		errs = append(errs, NewDepsError())
+		outcome = depsOutcome(syntaxResult, mxResult)
+	}
+
+	return NewResultWithOutcome(outcome, errs, nil, SMTPValidatorName)
+}
+
+// smtpOutcome returns OutcomeInvalid only if mailbox was rejected permanently on evsmtp.RCPTsStage.
+// Other errors (e.g. rejected sender, failed connection, evsmtp.CircuitOpenError or evsmtp.SMTPUTF8Error)
+// are problems of sender or server, the mailbox is not checked, so OutcomeUnknown is returned.
+func smtpOutcome(errs []error) Outcome {
+	if len(errs) == 0 {
+		return OutcomeValid
+	}
+
+	for _, err := range errs {
+		var smtpErr evsmtp.Error
+		if errors.As(err, &smtpErr) && smtpErr.Stage() == evsmtp.RCPTsStage && !evsmtp.IsTransient(err) {
+			return OutcomeInvalid
+		}
 	}
 
-	return NewResult(len(errs) == 0, errs, nil, SMTPValidatorName)
+	return OutcomeUnknown
 }
@@ -0,0 +1,193 @@
+package ev
+
+import (
+	"fmt"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/vmihailenco/msgpack"
+)
+
+// SubaddressValidatorName is name of subaddress validator
+const SubaddressValidatorName ValidatorName = "SubaddressValidator"
+
+// SubaddressErr is text for SubaddressError.Error
+const SubaddressErr = "SubaddressError"
+
+// SubaddressError is error or warning of SubaddressValidatorName
+type SubaddressError struct {
+	// Mailbox is base mailbox without tag, e.g. john@gmail.com for john+promo@gmail.com
+	Mailbox string
+	Tag     string
+	// Provider is provider of evmail.ProviderRule, it is empty for domains without rules
+	Provider string
+}
+
+func (s *SubaddressError) Error() string {
+	return fmt.Sprintf("%s: tag %q of mailbox %s", SubaddressErr, s.Tag, s.Mailbox)
+}
+
+// Code returns SubaddressCode
+func (s *SubaddressError) Code() string {
+	return SubaddressCode
+}
+
+// Category returns PolicyCategory
+func (s *SubaddressError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns mailbox, tag and provider
+func (s *SubaddressError) Details() map[string]interface{} {
+	return map[string]interface{}{
+		"mailbox":  s.Mailbox,
+		"tag":      s.Tag,
+		"provider": s.Provider,
+	}
+}
+
+// Severity is how detected problem is reported in result
+type Severity string
+
+// Severities of results
+const (
+	// SeverityError makes result invalid with error
+	SeverityError Severity = "error"
+	// SeverityWarning keeps result valid with warning
+	SeverityWarning Severity = "warning"
+	// SeverityInfo keeps result valid without errors and warnings, data is available only in result
+	SeverityInfo Severity = "info"
+)
+
+// Valid checks, whether severity is known
+func (s Severity) Valid() bool {
+	return s == SeverityError || s == SeverityWarning || s == SeverityInfo
+}
+
+// result returns result of validator name with err reported by severity
+func (s Severity) result(name ValidatorName, err error) *AValidationResult {
+	var result ValidationResult
+	switch s {
+	case SeverityError:
+		result = NewResult(false, utils.Errs(err), nil, name)
+	case SeverityWarning:
+		result = NewResult(true, nil, utils.Errs(err), name)
+	default:
+		result = NewValidResult(name)
+	}
+
+	return result.(*AValidationResult)
+}
+
+// DefaultSubaddressSeparators are separators of tags for domains without evmail.ProviderRule
+var DefaultSubaddressSeparators = []string{"+"}
+
+// SubaddressValidationResult is result of SubaddressValidatorName
+type SubaddressValidationResult interface {
+	// Mailbox is base mailbox without tag, it is nil if email is not subaddress
+	Mailbox() evmail.Address
+	// Tag is tag of subaddress, it can be empty for subaddress, e.g. john+@example.com
+	Tag() string
+	// IsSubaddress checks, whether email has tag
+	IsSubaddress() bool
+	ValidationResult
+}
+
+// NewSubaddressValidationResult instantiates SubaddressValidationResult
+func NewSubaddressValidationResult(mailbox evmail.Address, tag string, result *AValidationResult) SubaddressValidationResult {
+	return subaddressValidationResult{mailbox: mailbox, tag: tag, AValidationResult: result}
+}
+
+type subaddressValidationResult struct {
+	mailbox evmail.Address
+	tag     string
+	*AValidationResult
+}
+
+func (s subaddressValidationResult) Mailbox() evmail.Address {
+	return s.mailbox
+}
+
+func (s subaddressValidationResult) Tag() string {
+	return s.tag
+}
+
+func (s subaddressValidationResult) IsSubaddress() bool {
+	return s.mailbox != nil
+}
+
+// EncodeMsgpack implements encoder for msgpack, it keeps mailbox as string and tag in cache
+func (s subaddressValidationResult) EncodeMsgpack(enc *msgpack.Encoder) error {
+	var mailbox string
+	if s.mailbox != nil {
+		mailbox = s.mailbox.String()
+	}
+
+	return enc.EncodeMulti(s.AValidationResult, mailbox, s.tag)
+}
+
+// DecodeMsgpack implements decoder for msgpack
+func (s *subaddressValidationResult) DecodeMsgpack(dec *msgpack.Decoder) error {
+	var mailbox string
+	if err := dec.DecodeMulti(&s.AValidationResult, &mailbox, &s.tag); err != nil {
+		return err
+	}
+
+	if mailbox != "" {
+		s.mailbox = evmail.FromString(mailbox)
+	}
+
+	return nil
+}
+
+func (s *subaddressValidationResult) value() ValidationResult {
+	return *s
+}
+
+// SubaddressValidatorDTO is DTO for NewSubaddressValidator
+type SubaddressValidatorDTO struct {
+	// Rules are evmail.DefaultProviderRules() if nil, they are shared with evmail.Canonicalizer
+	Rules evmail.ProviderRules
+	// Separators are used for domains without rules, DefaultSubaddressSeparators if nil, e.g. "+" or "="
+	Separators []string
+	// Severity is SeverityWarning if empty
+	Severity Severity
+}
+
+// NewSubaddressValidator instantiates SubaddressValidatorName
+func NewSubaddressValidator(dto SubaddressValidatorDTO) Validator {
+	if dto.Rules == nil {
+		dto.Rules = evmail.DefaultProviderRules()
+	}
+	if dto.Separators == nil {
+		dto.Separators = DefaultSubaddressSeparators
+	}
+	if dto.Severity == "" {
+		dto.Severity = SeverityWarning
+	}
+
+	return subaddressValidator{dto: dto}
+}
+
+type subaddressValidator struct {
+	AValidatorWithoutDeps
+	dto SubaddressValidatorDTO
+}
+
+func (s subaddressValidator) Validate(input Input, _ ...ValidationResult) ValidationResult {
+	email := input.Email()
+	rule, ok := s.dto.Rules.Rule(evmail.ASCIIDomain(email))
+	if !ok {
+		rule = evmail.ProviderRule{TagSeparators: s.dto.Separators}
+	}
+
+	localPart, tag, isSubaddress := rule.SplitTag(email.Username())
+	if !isSubaddress {
+		return NewSubaddressValidationResult(nil, "", NewValidResult(SubaddressValidatorName).(*AValidationResult))
+	}
+
+	mailbox := evmail.NewEmailAddress(localPart, email.Domain())
+	err := &SubaddressError{Mailbox: mailbox.String(), Tag: tag, Provider: rule.Provider}
+
+	return NewSubaddressValidationResult(mailbox, tag, s.dto.Severity.result(SubaddressValidatorName, err))
+}
@@ -0,0 +1,157 @@
+package ev_test
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_subaddressValidator_Validate(t *testing.T) {
+	gmailErr := &ev.SubaddressError{Mailbox: "j.o.h.n@gmail.com", Tag: "promo", Provider: evmail.GmailProvider}
+	gmailMailbox := evmail.NewEmailAddress("j.o.h.n", "gmail.com")
+
+	tests := []struct {
+		name  string
+		dto   ev.SubaddressValidatorDTO
+		email evmail.Address
+		want  ev.ValidationResult
+	}{
+		{
+			name:  "without tag",
+			email: evmail.FromString("john@gmail.com"),
+			want:  ev.NewSubaddressValidationResult(nil, "", ev.NewValidResult(ev.SubaddressValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "warning by default",
+			email: evmail.FromString("j.o.h.n+promo@gmail.com"),
+			want: ev.NewSubaddressValidationResult(gmailMailbox, "promo",
+				ev.NewResult(true, nil, utils.Errs(gmailErr), ev.SubaddressValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "error",
+			dto:   ev.SubaddressValidatorDTO{Severity: ev.SeverityError},
+			email: evmail.FromString("j.o.h.n+promo@gmail.com"),
+			want: ev.NewSubaddressValidationResult(gmailMailbox, "promo",
+				ev.NewResult(false, utils.Errs(gmailErr), nil, ev.SubaddressValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "info",
+			dto:   ev.SubaddressValidatorDTO{Severity: ev.SeverityInfo},
+			email: evmail.FromString("j.o.h.n+promo@gmail.com"),
+			want: ev.NewSubaddressValidationResult(gmailMailbox, "promo",
+				ev.NewValidResult(ev.SubaddressValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "yahoo separator",
+			email: evmail.FromString("john-shop@yahoo.com"),
+			want: ev.NewSubaddressValidationResult(evmail.NewEmailAddress("john", "yahoo.com"), "shop",
+				ev.NewResult(true, nil, utils.Errs(&ev.SubaddressError{
+					Mailbox:  "john@yahoo.com",
+					Tag:      "shop",
+					Provider: evmail.YahooProvider,
+				}), ev.SubaddressValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "plus is not separator of yahoo",
+			email: evmail.FromString("john+shop@yahoo.com"),
+			want:  ev.NewSubaddressValidationResult(nil, "", ev.NewValidResult(ev.SubaddressValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "default separator of unknown domain",
+			email: evmail.FromString("john+shop@example.com"),
+			want: ev.NewSubaddressValidationResult(evmail.NewEmailAddress("john", "example.com"), "shop",
+				ev.NewResult(true, nil, utils.Errs(&ev.SubaddressError{
+					Mailbox: "john@example.com",
+					Tag:     "shop",
+				}), ev.SubaddressValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "custom separators",
+			dto:   ev.SubaddressValidatorDTO{Separators: []string{"="}},
+			email: evmail.FromString("john=shop@example.com"),
+			want: ev.NewSubaddressValidationResult(evmail.NewEmailAddress("john", "example.com"), "shop",
+				ev.NewResult(true, nil, utils.Errs(&ev.SubaddressError{
+					Mailbox: "john@example.com",
+					Tag:     "shop",
+				}), ev.SubaddressValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name: "custom rules",
+			dto: ev.SubaddressValidatorDTO{Rules: evmail.ProviderRules{
+				"example.com": {Provider: "example", TagSeparators: []string{"_"}},
+			}},
+			email: evmail.FromString("john_shop+x@example.com"),
+			want: ev.NewSubaddressValidationResult(evmail.NewEmailAddress("john", "example.com"), "shop+x",
+				ev.NewResult(true, nil, utils.Errs(&ev.SubaddressError{
+					Mailbox:  "john@example.com",
+					Tag:      "shop+x",
+					Provider: "example",
+				}), ev.SubaddressValidatorName).(*ev.AValidationResult)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ev.NewSubaddressValidator(tt.dto).Validate(ev.NewInput(tt.email))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSubaddressValidationResult(t *testing.T) {
+	got := ev.NewSubaddressValidator(ev.SubaddressValidatorDTO{}).
+		Validate(ev.NewInput(evmail.FromString("john+@outlook.com"))).(ev.SubaddressValidationResult)
+
+	require.True(t, got.IsSubaddress())
+	require.Equal(t, "john@outlook.com", got.Mailbox().String())
+	require.Equal(t, "", got.Tag())
+}
+
+func TestSubaddressError_Error(t *testing.T) {
+	err := &ev.SubaddressError{Mailbox: "john@gmail.com", Tag: "promo"}
+
+	require.Equal(t, `SubaddressError: tag "promo" of mailbox john@gmail.com`, err.Error())
+}
+
+func TestSubaddressParams_Validate(t *testing.T) {
+	registry := ev.DefaultRegistry()
+
+	_, err := registry.New(ev.SubaddressValidatorName, ev.SubaddressParams{Severity: ev.SeverityInfo, Separators: []string{"="}})
+	require.NoError(t, err)
+	_, err = registry.New(ev.SubaddressValidatorName, ev.SubaddressParams{Severity: "fatal"})
+	require.EqualError(t, err, `ParamsError: SubaddressValidator: severity: unknown severity "fatal"`)
+	_, err = registry.New(ev.SubaddressValidatorName, ev.SubaddressParams{Separators: []string{""}})
+	require.EqualError(t, err, "ParamsError: SubaddressValidator: separators: empty separator")
+}
+
+func TestDepBuilder_Subaddress(t *testing.T) {
+	builder := ev.NewDepBuilder(ev.ValidatorMap{})
+	require.NoError(t, builder.SetByName(ev.SubaddressValidatorName, ev.SubaddressParams{Severity: ev.SeverityError}))
+
+	got := builder.Build().Validate(ev.NewInput(evmail.FromString("john+promo@gmail.com")))
+	require.False(t, got.IsValid())
+	require.Equal(t, "john", got.(ev.DepValidationResult).GetResults()[ev.SubaddressValidatorName].(ev.SubaddressValidationResult).Mailbox().Username())
+}
+
+func TestParseConfig_Subaddress(t *testing.T) {
+	config, err := ev.ParseConfig([]byte(`
+validators:
+  - name: SubaddressValidator
+    severity: error
+    separators: ["="]
+`))
+	require.NoError(t, err)
+
+	builder, err := config.Builder()
+	require.NoError(t, err)
+	require.False(t, builder.Get(ev.SubaddressValidatorName).Validate(ev.NewInput(evmail.FromString("john=shop@example.com"))).IsValid())
+
+	_, err = ev.ParseConfig([]byte(`
+validators:
+  - name: SubaddressValidator
+    severity: fatal
+`))
+	require.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package ev
+
+import (
+	"fmt"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsuggest"
+	"github.com/vmihailenco/msgpack"
+)
+
+// SuggestionValidatorName is name of validator of misspelled domains
+const SuggestionValidatorName ValidatorName = "SuggestionValidator"
+
+// SuggestionErr is text for SuggestionError.Error
+const SuggestionErr = "SuggestionError"
+
+// SuggestionError is error or warning of SuggestionValidatorName
+type SuggestionError struct {
+	Domain     string
+	Suggestion string
+	Confidence float64
+}
+
+func (s *SuggestionError) Error() string {
+	return fmt.Sprintf("%s: did you mean %s instead of %s", SuggestionErr, s.Suggestion, s.Domain)
+}
+
+// Code returns SuggestionCode
+func (s *SuggestionError) Code() string {
+	return SuggestionCode
+}
+
+// Category returns DomainCategory
+func (s *SuggestionError) Category() string {
+	return DomainCategory
+}
+
+// Details returns domain, suggestion and confidence
+func (s *SuggestionError) Details() map[string]interface{} {
+	return map[string]interface{}{
+		"domain":     s.Domain,
+		"suggestion": s.Suggestion,
+		"confidence": s.Confidence,
+	}
+}
+
+// SuggestionValidationResult is result of SuggestionValidatorName
+type SuggestionValidationResult interface {
+	// Suggestion is suggested domain, it is empty if domain is not misspelled
+	Suggestion() string
+	// Confidence is from 0 to 1
+	Confidence() float64
+	ValidationResult
+}
+
+// NewSuggestionValidationResult instantiates SuggestionValidationResult
+func NewSuggestionValidationResult(suggestion string, confidence float64, result *AValidationResult) SuggestionValidationResult {
+	return suggestionValidationResult{suggestion: suggestion, confidence: confidence, AValidationResult: result}
+}
+
+type suggestionValidationResult struct {
+	suggestion string
+	confidence float64
+	*AValidationResult
+}
+
+func (s suggestionValidationResult) Suggestion() string {
+	return s.suggestion
+}
+
+func (s suggestionValidationResult) Confidence() float64 {
+	return s.confidence
+}
+
+// EncodeMsgpack implements encoder for msgpack, it keeps suggestion and confidence in cache
+func (s suggestionValidationResult) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeMulti(s.AValidationResult, s.suggestion, s.confidence)
+}
+
+// DecodeMsgpack implements decoder for msgpack
+func (s *suggestionValidationResult) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return dec.DecodeMulti(&s.AValidationResult, &s.suggestion, &s.confidence)
+}
+
+func (s *suggestionValidationResult) value() ValidationResult {
+	return *s
+}
+
+// SuggestionValidatorDTO is DTO for NewSuggestionValidator
+type SuggestionValidatorDTO struct {
+	// Suggester is evsuggest.NewSuggester with free domains and evsuggest.DefaultPopularDomains() if nil
+	Suggester *evsuggest.Suggester
+	// Severity is SeverityWarning if empty
+	Severity Severity
+}
+
+// NewSuggestionValidator instantiates SuggestionValidatorName
+func NewSuggestionValidator(dto SuggestionValidatorDTO) Validator {
+	if dto.Suggester == nil {
+		dto.Suggester = evsuggest.NewSuggester(evsuggest.SuggesterDTO{})
+	}
+	if dto.Severity == "" {
+		dto.Severity = SeverityWarning
+	}
+
+	return suggestionValidator{dto: dto}
+}
+
+type suggestionValidator struct {
+	AValidatorWithoutDeps
+	dto SuggestionValidatorDTO
+}
+
+func (s suggestionValidator) Validate(input Input, _ ...ValidationResult) ValidationResult {
+	domain := evmail.ASCIIDomain(input.Email())
+	suggestion, ok := s.dto.Suggester.Suggest(domain)
+	if !ok {
+		return NewSuggestionValidationResult("", 0, NewValidResult(SuggestionValidatorName).(*AValidationResult))
+	}
+
+	err := &SuggestionError{Domain: domain, Suggestion: suggestion.Domain, Confidence: suggestion.Confidence}
+
+	return NewSuggestionValidationResult(suggestion.Domain, suggestion.Confidence, s.dto.Severity.result(SuggestionValidatorName, err))
+}
@@ -0,0 +1,113 @@
+package ev_test
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsuggest"
+	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_suggestionValidator_Validate(t *testing.T) {
+	gmailErr := &ev.SuggestionError{Domain: "gmial.com", Suggestion: "gmail.com", Confidence: 1 - 0.5/9}
+
+	tests := []struct {
+		name  string
+		dto   ev.SuggestionValidatorDTO
+		email evmail.Address
+		want  ev.ValidationResult
+	}{
+		{
+			name:  "known domain",
+			email: evmail.FromString("john@gmail.com"),
+			want:  ev.NewSuggestionValidationResult("", 0, ev.NewValidResult(ev.SuggestionValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "unknown domain",
+			email: evmail.FromString("john@mycompany.io"),
+			want:  ev.NewSuggestionValidationResult("", 0, ev.NewValidResult(ev.SuggestionValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "warning by default",
+			email: evmail.FromString("john@gmial.com"),
+			want: ev.NewSuggestionValidationResult("gmail.com", gmailErr.Confidence,
+				ev.NewResult(true, nil, utils.Errs(gmailErr), ev.SuggestionValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "error",
+			dto:   ev.SuggestionValidatorDTO{Severity: ev.SeverityError},
+			email: evmail.FromString("john@gmial.com"),
+			want: ev.NewSuggestionValidationResult("gmail.com", gmailErr.Confidence,
+				ev.NewResult(false, utils.Errs(gmailErr), nil, ev.SuggestionValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "info",
+			dto:   ev.SuggestionValidatorDTO{Severity: ev.SeverityInfo},
+			email: evmail.FromString("john@gmial.com"),
+			want: ev.NewSuggestionValidationResult("gmail.com", gmailErr.Confidence,
+				ev.NewValidResult(ev.SuggestionValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name:  "misspelled TLD",
+			email: evmail.FromString("john@example.con"),
+			want: ev.NewSuggestionValidationResult("example.com", 1-0.5/3,
+				ev.NewResult(true, nil, utils.Errs(&ev.SuggestionError{
+					Domain:     "example.con",
+					Suggestion: "example.com",
+					Confidence: 1 - 0.5/3,
+				}), ev.SuggestionValidatorName).(*ev.AValidationResult)),
+		},
+		{
+			name: "custom suggester",
+			dto: ev.SuggestionValidatorDTO{Suggester: evsuggest.NewSuggester(evsuggest.SuggesterDTO{
+				Domains:     []string{"example.com"},
+				WithoutFree: true,
+			})},
+			email: evmail.FromString("john@gmial.com"),
+			want:  ev.NewSuggestionValidationResult("", 0, ev.NewValidResult(ev.SuggestionValidatorName).(*ev.AValidationResult)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ev.NewSuggestionValidator(tt.dto).Validate(ev.NewInput(tt.email))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSuggestionError_Error(t *testing.T) {
+	err := &ev.SuggestionError{Domain: "gmial.com", Suggestion: "gmail.com", Confidence: 0.9}
+
+	require.Equal(t, "SuggestionError: did you mean gmail.com instead of gmial.com", err.Error())
+}
+
+func TestSuggestionParams_Validate(t *testing.T) {
+	registry := ev.DefaultRegistry()
+
+	_, err := registry.New(ev.SuggestionValidatorName, ev.SuggestionParams{Severity: ev.SeverityInfo, Domains: []string{"example.com"}})
+	require.NoError(t, err)
+	_, err = registry.New(ev.SuggestionValidatorName, ev.SuggestionParams{Severity: "fatal"})
+	require.EqualError(t, err, `ParamsError: SuggestionValidator: severity: unknown severity "fatal"`)
+	_, err = registry.New(ev.SuggestionValidatorName, ev.SuggestionParams{MinConfidence: 1.5})
+	require.EqualError(t, err, "ParamsError: SuggestionValidator: minConfidence: should be from 0 to 1")
+}
+
+func TestParseConfig_Suggestion(t *testing.T) {
+	config, err := ev.ParseConfig([]byte(`
+validators:
+  - name: SuggestionValidator
+    severity: error
+    domains: ["example.com"]
+    minConfidence: 0.5
+`))
+	require.NoError(t, err)
+
+	builder, err := config.Builder()
+	require.NoError(t, err)
+
+	got := builder.Get(ev.SuggestionValidatorName).Validate(ev.NewInput(evmail.FromString("john@exmaple.com")))
+	require.False(t, got.IsValid())
+	require.Equal(t, "example.com", got.(ev.SuggestionValidationResult).Suggestion())
+}
@@ -20,6 +20,21 @@ func (SyntaxError) Error() string {
 	return SyntaxErr
 }
 
+// Code returns SyntaxCode
+func (SyntaxError) Code() string {
+	return SyntaxCode
+}
+
+// Category returns SyntaxCategory
+func (SyntaxError) Category() string {
+	return SyntaxCategory
+}
+
+// Details returns nil
+func (SyntaxError) Details() map[string]interface{} {
+	return nil
+}
+
 // SyntaxValidatorResult is interface of SyntaxValidatorName result
 type SyntaxValidatorResult interface {
 	ValidationResult
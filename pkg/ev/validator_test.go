@@ -1,7 +1,13 @@
 package ev_test
 
 import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
 	"reflect"
 	"sort"
 	"strings"
@@ -9,9 +15,11 @@ import (
 
 	"github.com/prodadidb/go-email-validator/pkg/ev"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evtests"
 	"github.com/prodadidb/go-email-validator/pkg/ev/utils"
 	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack"
 )
 
 // Test constants
@@ -103,6 +111,18 @@ func (m mockValidator) GetDeps() []ev.ValidatorName {
 	return m.deps
 }
 
+type mockCtxKey struct{}
+
+// mockContextValidator returns valid result only if ctx has want value by mockCtxKey
+type mockContextValidator struct {
+	mockValidator
+	want interface{}
+}
+
+func (m mockContextValidator) ValidateContext(ctx context.Context, input ev.Input, results ...ev.ValidationResult) ev.ValidationResult {
+	return newMockValidator(ctx.Value(mockCtxKey{}) == m.want).Validate(input, results...)
+}
+
 type mockValidationResult struct {
 	errs  []error
 	warns []error
@@ -113,6 +133,10 @@ func (m mockValidationResult) IsValid() bool {
 	return m.HasErrors()
 }
 
+func (m mockValidationResult) Outcome() ev.Outcome {
+	return ev.OutcomeOf(m.IsValid())
+}
+
 func (m mockValidationResult) Errors() []error {
 	return m.errs
 }
@@ -321,3 +345,127 @@ func TestAValidationResult_ValidatorName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), mockCtxKey{}, mockValidatorName)
+
+	tests := []struct {
+		name      string
+		validator ev.Validator
+		want      ev.ValidationResult
+	}{
+		{
+			name:      "context validator",
+			validator: mockContextValidator{mockValidator: newMockValidator(false), want: mockValidatorName},
+			want:      validResult,
+		},
+		{
+			name:      "context validator with another value",
+			validator: mockContextValidator{mockValidator: newMockValidator(true), want: ev.OtherValidator},
+			want:      invalidResult,
+		},
+		{
+			name:      "validator without context",
+			validator: newMockValidator(false),
+			want:      invalidResult,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ev.ValidateContext(ctx, tt.validator, ev.NewInput(validEmail))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// msgpackExtID returns id of msgpack extension, which encodes value
+func msgpackExtID(t *testing.T, value interface{}) int8 {
+	data, err := msgpack.Marshal(value)
+	require.NoError(t, err)
+
+	switch data[0] {
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8: // fixext
+		return int8(data[1])
+	case 0xc7: // ext 8
+		return int8(data[2])
+	case 0xc8: // ext 16
+		return int8(data[3])
+	case 0xc9: // ext 32
+		return int8(data[5])
+	}
+	require.Failf(t, "value is not encoded as extension", "%T: %x", value, data)
+
+	return 0
+}
+
+// TestMsgpackExtIDs pins ids of msgpack extensions, ids are stored in caches, so they should not be changed
+func TestMsgpackExtIDs(t *testing.T) {
+	result := ev.NewValidResult(ev.OtherValidator).(*ev.AValidationResult)
+
+	tests := []struct {
+		value interface{}
+		want  int8
+	}{
+		{value: &evsmtp.DefaultError{}, want: 0},
+		{value: &textproto.Error{}, want: 2},
+		{value: &net.DNSError{}, want: 6},
+		{value: &x509.ConstraintViolationError{}, want: 20},
+		{value: ev.NewDepsError(), want: 21},
+		{value: result, want: 22},
+		{value: &ev.PanicError{}, want: 23},
+		{value: &ev.RetryError{}, want: 24},
+		{value: &ev.RateLimitedError{}, want: 25},
+		{value: &evsmtp.CircuitOpenError{}, want: 26},
+		{value: &evsmtp.SMTPUTF8Error{}, want: 27},
+		{value: &ev.SubaddressError{}, want: 28},
+		{value: &ev.SuggestionError{}, want: 29},
+		{value: ev.NewMXValidationResult(nil, result), want: 30},
+		{value: ev.NewGravatarValidationResult("", result), want: 31},
+		{value: ev.NewSubaddressValidationResult(nil, "", result), want: 32},
+		{value: ev.NewSuggestionValidationResult("", 0, result), want: 33},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%T", tt.value), func(t *testing.T) {
+			require.Equal(t, tt.want, msgpackExtID(t, tt.value))
+		})
+	}
+}
+
+func TestAValidationResult_Msgpack(t *testing.T) {
+	smtpErrs := utils.Errs(evsmtp.NewError(evsmtp.RCPTsStage, &textproto.Error{Code: 550, Msg: "user unknown"}))
+
+	tests := []struct {
+		name string
+		data func() []byte
+		want ev.ValidationResult
+	}{
+		{
+			// encoded by release without UnknownVal
+			name: "previous layout",
+			data: func() []byte {
+				data, err := hex.DecodeString("c73c16c291c72500cc05c7200282a4436f6465d30000000000000226a34d7367ac" +
+					"7573657220756e6b6e6f776e91d41580ad534d545056616c696461746f72")
+				require.NoError(t, err)
+				return data
+			},
+			want: ev.NewResult(false, smtpErrs, utils.Errs(ev.NewDepsError()), ev.SMTPValidatorName),
+		},
+		{
+			name: "unknown",
+			data: func() []byte {
+				result := ev.NewResultWithOutcome(ev.OutcomeUnknown, smtpErrs, utils.Errs(ev.NewDepsError()), ev.SMTPValidatorName)
+				data, err := msgpack.Marshal(&result)
+				require.NoError(t, err)
+				return data
+			},
+			want: ev.NewResultWithOutcome(ev.OutcomeUnknown, smtpErrs, utils.Errs(ev.NewDepsError()), ev.SMTPValidatorName),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ev.ValidationResult
+			require.NoError(t, msgpack.Unmarshal(tt.data(), &got))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -18,6 +18,21 @@ func (WhiteListError) Error() string {
 	return WhiteListErr
 }
 
+// Code returns WhiteListCode
+func (WhiteListError) Code() string {
+	return WhiteListCode
+}
+
+// Category returns PolicyCategory
+func (WhiteListError) Category() string {
+	return PolicyCategory
+}
+
+// Details returns nil
+func (WhiteListError) Details() map[string]interface{} {
+	return nil
+}
+
 // NewWhiteListValidator instantiates WhiteListDomainValidatorName
 func NewWhiteListValidator(d contains.InSet) Validator {
 	return whiteListValidator{d: d}
@@ -65,7 +65,7 @@ func (s DepConverter) Convert(email evmail.Address, resultInterface ev.Validatio
 	var syntax *SyntaxPresentation
 	var smtp *SMTPPresentation
 	var gravatar *GravatarPresentation
-	mxResult := validationResults[ev.MXValidatorName].(ev.MXValidationResult)
+	mxResult := validationResults[ev.MXValidatorName]
 	for _, validatorResult := range depResult.GetResults() {
 		if !s.converter.Can(email, validatorResult, opts) {
 			continue
@@ -97,6 +97,10 @@ func (s DepConverter) Convert(email evmail.Address, resultInterface ev.Validatio
 		return depPresentation
 	}
 
+	if suggestion, ok := validationResults[ev.SuggestionValidatorName].(ev.SuggestionValidationResult); ok {
+		depPresentation.Suggestion = suggestion.Suggestion()
+	}
+
 	depPresentation.Free = !validationResults[ev.FreeValidatorName].IsValid()
 	depPresentation.RoleAccount = !validationResults[ev.RoleValidatorName].IsValid()
 	depPresentation.Disposable = !validationResults[ev.DisposableValidatorName].IsValid()
@@ -109,7 +113,7 @@ func (s DepConverter) Convert(email evmail.Address, resultInterface ev.Validatio
 		return depPresentation
 	}
 
-	depPresentation.HasMxRecords = len(mxResult.MX()) > 0
+	depPresentation.HasMxRecords = len(converter.MXOf(mxResult)) > 0
 
 	if smtp == nil || !smtp.HostExists {
 		return depPresentation
@@ -139,5 +143,6 @@ func NewDepValidator(smtpValidator ev.Validator) ev.Validator {
 		Set(ev.GravatarValidatorName, ev.NewGravatarValidator()).
 		Set(ev.SMTPValidatorName, smtpValidator).
 		Set(ev.FreeValidatorName, ev.FreeDefaultValidator()).
+		Set(ev.SuggestionValidatorName, ev.NewSuggestionValidator(ev.SuggestionValidatorDTO{})).
 		Build()
 }
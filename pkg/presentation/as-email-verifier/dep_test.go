@@ -0,0 +1,19 @@
+package asemailverifier
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evtests"
+	"github.com/prodadidb/go-email-validator/pkg/presentation/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepConverter_Convert_MXTimeout(t *testing.T) {
+	email := "user@example.com"
+
+	got := NewDepConverterDefault().Convert(evmail.FromString(email), evtests.MXTimeoutDepResult(email), converter.NewOptions(0)).(DepPresentation)
+	require.Equal(t, ReachableUnknown, got.Reachable)
+	require.False(t, got.HasMxRecords)
+	require.Nil(t, got.SMTP)
+}
@@ -22,8 +22,8 @@ func (GravatarConverter) Can(_ evmail.Address, result ev.ValidationResult, _ con
 
 // Convert ev.ValidationResult in GravatarPresentation
 func (GravatarConverter) Convert(_ evmail.Address, result ev.ValidationResult, _ converter.Options) interface{} {
-	gravatarResult := result.(ev.GravatarValidationResult)
-	presentation := &GravatarPresentation{HasGravatar: gravatarResult.IsValid()}
+	gravatarResult, ok := result.(ev.GravatarValidationResult)
+	presentation := &GravatarPresentation{HasGravatar: ok && gravatarResult.IsValid()}
 
 	if presentation.HasGravatar {
 		presentation.GravatarURL = gravatarResult.URL()
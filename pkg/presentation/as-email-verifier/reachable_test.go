@@ -0,0 +1,24 @@
+package asemailverifier
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evtests"
+	"github.com/prodadidb/go-email-validator/pkg/presentation/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateReachable_ScorePreset(t *testing.T) {
+	rules, err := ev.ScorePreset(ev.ASEmailVerifierScore)
+	require.NoError(t, err)
+
+	d := NewDepConverterDefault()
+	email := evmail.FromString("user@example.com")
+	for _, result := range evtests.DepResultsCombinations() {
+		presentation := d.Convert(email, result, converter.NewOptions(0)).(DepPresentation)
+
+		require.Equal(t, presentation.Reachable.String(), rules.Score(email, result).Level, "%v", result)
+	}
+}
@@ -0,0 +1,17 @@
+package checkifemailexist
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evtests"
+	"github.com/prodadidb/go-email-validator/pkg/presentation/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepConverter_Convert_MXTimeout(t *testing.T) {
+	email := "user@example.com"
+
+	got := NewDepConverterDefault().Convert(evmail.FromString(email), evtests.MXTimeoutDepResult(email), converter.NewOptions(0)).(DepPresentation)
+	require.Equal(t, mxPresentation{AcceptsMail: false, Records: []string{}}, got.MX)
+}
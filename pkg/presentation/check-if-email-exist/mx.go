@@ -18,16 +18,10 @@ func (mxConverter) Can(_ evmail.Address, result ev.ValidationResult, _ converter
 }
 
 func (mxConverter) Convert(_ evmail.Address, result ev.ValidationResult, _ converter.Options) interface{} {
-	mxResult := result.(ev.MXValidationResult)
-	lenMX := len(mxResult.MX())
-	records := make([]string, lenMX)
-
-	for i, mx := range mxResult.MX() {
-		records[i] = mx.Host
-	}
+	records := converter.MX2String(converter.MXOf(result))
 
 	return mxPresentation{
-		lenMX > 0,
+		len(records) > 0,
 		records,
 	}
 }
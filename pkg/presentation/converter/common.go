@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"github.com/prodadidb/go-email-validator/pkg/ev"
 	"github.com/prodadidb/go-email-validator/pkg/ev/evsmtp"
 )
 
@@ -13,3 +14,13 @@ func MX2String(MXs evsmtp.MXs) []string {
 
 	return result
 }
+
+// MXOf returns MX records of ev.MXValidationResult.
+// Results of timed out, panicked or skipped validators are not ev.MXValidationResult, nil is returned for them.
+func MXOf(result ev.ValidationResult) evsmtp.MXs {
+	if mxResult, ok := result.(ev.MXValidationResult); ok {
+		return mxResult.MX()
+	}
+
+	return nil
+}
@@ -72,13 +72,15 @@ func (SMTPConverter) Convert(_ evmail.Address, result ev.ValidationResult, _ Opt
 	var errCode int
 	var smtpError evsmtp.Error
 	var depError *ev.DepsError
+	var skippedError *ev.SkippedError
+	var notApplicableError ev.NotApplicableError
 
 	errs := result.Errors()
 	errs = append(errs, result.Warnings()...)
 
 	for _, err := range errs {
 		if !errors.As(err, &smtpError) {
-			if errors.As(err, &depError) {
+			if errors.As(err, &depError) || errors.As(err, &skippedError) || errors.As(err, &notApplicableError) {
 				return FalseSMTPPresentation
 			}
 			continue
@@ -97,7 +99,7 @@ func (SMTPConverter) Convert(_ evmail.Address, result ev.ValidationResult, _ Opt
 		}
 
 		switch smtpError.Stage() {
-		case evsmtp.ConnectionStage:
+		case evsmtp.ConnectionStage, evsmtp.CircuitOpenStage:
 			presentation = FalseSMTPPresentation
 		case evsmtp.HelloStage,
 			evsmtp.AuthStage,
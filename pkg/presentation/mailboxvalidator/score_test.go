@@ -1,9 +1,16 @@
 package mailboxvalidator
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/emirpasic/gods/sets/hashset"
+	"github.com/prodadidb/go-email-validator/pkg/ev"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evtests"
+	"github.com/prodadidb/go-email-validator/pkg/presentation/converter"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCalculateScore(t *testing.T) {
@@ -26,3 +33,20 @@ func TestCalculateScore(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateScore_ScorePreset(t *testing.T) {
+	rules, err := ev.ScorePreset(ev.MailboxValidatorScore)
+	require.NoError(t, err)
+
+	d := NewDepConverterDefault()
+	opts := converter.NewOptions(time.Second)
+	for _, email := range []evmail.Address{evmail.FromString("user@example.com"), evmail.FromString("user.1@example.com")} {
+		for _, result := range evtests.DepResultsCombinations() {
+			presentation := d.Convert(email, result, opts).(DepPresentation)
+			got := rules.Score(email, result)
+
+			require.InDelta(t, math.Max(presentation.MailboxvalidatorScore, 0), got.Score, 1e-9, "%v %v", email, result)
+			require.Equal(t, presentation.Status.ToBool(), got.Level == "valid")
+		}
+	}
+}
@@ -54,7 +54,7 @@ func (d DepConverter) Convert(email evmail.Address, resultInterface ev.Validatio
 	var message string
 	depResult := resultInterface.(ev.DepValidationResult)
 	validationResults := depResult.GetResults()
-	mxResult := validationResults[ev.MXValidatorName].(ev.MXValidationResult)
+	mxResult := validationResults[ev.MXValidatorName]
 
 	smtpPresentation := converter.NewSMTPConverter().Convert(email, validationResults[ev.SMTPValidatorName], nil).(converter.SMTPPresentation)
 
@@ -76,7 +76,7 @@ func (d DepConverter) Convert(email evmail.Address, resultInterface ev.Validatio
 		IsDisabled:     smtpPresentation.IsDisabled,
 		MxRecords: mx{
 			AcceptsMail: mxResult.IsValid(),
-			Records:     converter.MX2String(mxResult.MX()),
+			Records:     converter.MX2String(converter.MXOf(mxResult)),
 		},
 		Message: message,
 	}
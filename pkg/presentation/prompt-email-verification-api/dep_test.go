@@ -0,0 +1,19 @@
+package promptemailverificationapi
+
+import (
+	"testing"
+
+	"github.com/prodadidb/go-email-validator/pkg/ev/evmail"
+	"github.com/prodadidb/go-email-validator/pkg/ev/evtests"
+	"github.com/prodadidb/go-email-validator/pkg/presentation/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepConverter_Convert_MXTimeout(t *testing.T) {
+	email := "user@example.com"
+
+	got := NewDepConverter().Convert(evmail.FromString(email), evtests.MXTimeoutDepResult(email), converter.NewOptions(0)).(DepPresentation)
+	require.True(t, got.SyntaxValid)
+	require.Equal(t, mx{AcceptsMail: false, Records: []string{}}, got.MxRecords)
+	require.False(t, got.CanConnectSMTP)
+}